@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HannaLindgren/go-utils/tools"
+)
+
+const cmdname = "goutils"
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Multi-call binary for the go-utils commands")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> <args>\n", cmdname)
+	fmt.Fprintf(os.Stderr, "       %s help [command]\n", cmdname)
+	fmt.Fprintln(os.Stderr, "       or, using a symlink named as the command (busybox style)")
+	fmt.Fprintf(os.Stderr, "       ln -s %s upcase; ./upcase <args>\n", cmdname)
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	tools.PrintHelp(os.Stderr)
+}
+
+// run runs the tool, as if it was called with the command line arguments args
+func run(t tools.Tool, args []string) {
+	os.Args = append([]string{t.Name}, args...)
+	flag.CommandLine = flag.NewFlagSet(t.Name, flag.ExitOnError)
+	t.Main()
+}
+
+func main() {
+	// called through a symlink named as the command
+	if t, ok := tools.Lookup(os.Args[0]); ok {
+		run(t, os.Args[1:])
+		return
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		if len(args) == 0 {
+			printUsage()
+			return
+		}
+		name, args = args[0], []string{"-h"}
+	}
+	t, ok := tools.Lookup(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "[error] unknown command: %s\n\n", filepath.Base(name))
+		printUsage()
+		os.Exit(1)
+	}
+	run(t, args)
+}
@@ -253,8 +253,9 @@ func NewStringReader(source string, separator string) *Reader {
 	return NewReader(lines, separator)
 }
 
+// NewFileReader creates a reader for the input file. A UTF-8 byte order mark is removed, and CRLF/CR line endings are normalised, so that header matching works for files saved on windows.
 func NewFileReader(fName string, separator string) (*Reader, error) {
-	lines, err := io.ReadFileToLines(fName)
+	lines, err := io.ReadFileToLinesWithOptions(fName, io.TextReadOptions)
 	//file, err := os.Open(fName)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,311 @@
+package io
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ArchiveMemberSep separates the archive file from the member name in an archive path, e.g. bundle.zip!data/lex.tsv
+const ArchiveMemberSep = "!"
+
+var archiveExtensions = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// IsArchive returns true if the file name has a supported archive extension (.zip, .tar, .tar.gz or .tgz)
+func IsArchive(fName string) bool {
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(fName, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitArchivePath splits an archive path, such as bundle.tar.gz!lex.tsv, into the archive file name and the member name. If the input is not an archive path, ok is false.
+func SplitArchivePath(fName string) (archive string, member string, ok bool) {
+	for _, ext := range archiveExtensions {
+		if i := strings.Index(fName, ext+ArchiveMemberSep); i >= 0 {
+			end := i + len(ext)
+			// prefer the longest extension (.tar.gz over .tar)
+			if !ok || end > len(archive) {
+				archive = fName[:end]
+				member = fName[end+len(ArchiveMemberSep):]
+				ok = true
+			}
+		}
+	}
+	if ok && member == "" {
+		return "", "", false
+	}
+	return archive, member, ok
+}
+
+// isArchivePath returns true for archive paths that do not exist as plain files
+func isArchivePath(fName string) bool {
+	if _, _, ok := SplitArchivePath(fName); !ok {
+		return false
+	}
+	if _, err := os.Stat(fName); err == nil {
+		return false
+	}
+	return true
+}
+
+func cleanMemberName(name string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "./")
+}
+
+func newZipReader(fh *os.File, archive string) (*zip.Reader, error) {
+	info, err := fh.Stat()
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(fh, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open zip reader for %s : %v", archive, err)
+	}
+	return zr, nil
+}
+
+func newTarReader(fh *os.File, archive string) (*tar.Reader, error) {
+	var r io.Reader = fh
+	if strings.HasSuffix(archive, ".gz") || strings.HasSuffix(archive, ".tgz") {
+		gz, err := gzip.NewReader(fh)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't to open gz reader : %v", err)
+		}
+		r = gz
+	}
+	return tar.NewReader(r), nil
+}
+
+// walkArchive calls fn for each regular file in the archive, until fn returns stop=true or an error
+func walkArchive(archive string, fn func(name string, r io.Reader) (bool, error)) error {
+	fh, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return fmt.Errorf("couldn't open archive %s for reading : %v", archive, err)
+	}
+	defer fh.Close()
+
+	if strings.HasSuffix(archive, ".zip") {
+		zr, err := newZipReader(fh, archive)
+		if err != nil {
+			return err
+		}
+		for _, f := range zr.File {
+			if !f.Mode().IsRegular() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("couldn't open zip member %s : %v", f.Name, err)
+			}
+			stop, err := fn(cleanMemberName(f.Name), rc)
+			rc.Close()
+			if stop || err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tr, err := newTarReader(fh, archive)
+	if err != nil {
+		return err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't read tar archive %s : %v", archive, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		stop, err := fn(cleanMemberName(hdr.Name), tr)
+		if stop || err != nil {
+			return err
+		}
+	}
+}
+
+// multiCloser closes each of its closers in order, returning the first error
+type multiCloser []io.Closer
+
+func (mc multiCloser) Close() error {
+	var res error
+	for _, c := range mc {
+		if err := c.Close(); err != nil && res == nil {
+			res = err
+		}
+	}
+	return res
+}
+
+// closeOnEOFReader closes the underlying closer when the reader returns an error (including io.EOF)
+type closeOnEOFReader struct {
+	r      io.Reader
+	closer io.Closer
+}
+
+func (r *closeOnEOFReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && r.closer != nil {
+		r.closer.Close()
+		r.closer = nil
+	}
+	return n, err
+}
+
+// getArchiveMemberReader returns a reader for an archive member, along with the archive file handle and a closer for the member reader (a zip member or a gzip reader), that both need to be closed after reading
+func getArchiveMemberReader(archive, member string) (io.Reader, *os.File, io.Closer, error) {
+	member = cleanMemberName(member)
+	fh, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return nil, fh, nil, fmt.Errorf("couldn't open archive %s for reading : %v", archive, err)
+	}
+
+	var r io.Reader
+	closers := multiCloser{}
+	if strings.HasSuffix(archive, ".zip") {
+		zr, err := newZipReader(fh, archive)
+		if err != nil {
+			return nil, fh, nil, err
+		}
+		for _, f := range zr.File {
+			if f.Mode().IsRegular() && cleanMemberName(f.Name) == member {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, fh, nil, fmt.Errorf("couldn't open zip member %s : %v", f.Name, err)
+				}
+				r = rc
+				closers = append(closers, rc)
+				break
+			}
+		}
+	} else {
+		tr, err := newTarReader(fh, archive)
+		if err != nil {
+			return nil, fh, nil, err
+		}
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fh, nil, fmt.Errorf("couldn't read tar archive %s : %v", archive, err)
+			}
+			if hdr.Typeflag == tar.TypeReg && cleanMemberName(hdr.Name) == member {
+				r = tr
+				break
+			}
+		}
+	}
+	if r == nil {
+		return nil, fh, nil, fmt.Errorf("no member %s in archive %s", member, archive)
+	}
+
+	if strings.HasSuffix(member, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			closers.Close()
+			return nil, fh, nil, fmt.Errorf("couldn't to open gz reader : %v", err)
+		}
+		// the gzip reader is closed before the member reader
+		closers = append(multiCloser{gz}, closers...)
+		return io.Reader(gz), fh, closers, nil
+	}
+	return r, fh, closers, nil
+}
+
+// ListArchiveMembers returns the names of all regular files in a zip or tar archive, in archive order
+func ListArchiveMembers(archive string) ([]string, error) {
+	res := []string{}
+	err := walkArchive(archive, func(name string, r io.Reader) (bool, error) {
+		res = append(res, name)
+		return false, nil
+	})
+	return res, err
+}
+
+// ListArchiveTextMembers returns the names of the text files in a zip or tar archive, in archive order. A member is considered to be text if its first bytes are valid UTF-8 without any NUL characters (gzipped members are uncompressed before checking).
+func ListArchiveTextMembers(archive string) ([]string, error) {
+	res := []string{}
+	err := walkArchive(archive, func(name string, r io.Reader) (bool, error) {
+		if strings.HasSuffix(name, ".gz") {
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				// not gzipped after all, hence not text
+				return false, nil
+			}
+			r = gz
+		}
+		isText, err := looksLikeText(r)
+		if err != nil {
+			return false, fmt.Errorf("couldn't read archive member %s : %v", name, err)
+		}
+		if isText {
+			res = append(res, name)
+		}
+		return false, nil
+	})
+	return res, err
+}
+
+const sniffLen = 512
+
+func looksLikeText(r io.Reader) (bool, error) {
+	head, err := bufio.NewReaderSize(r, sniffLen).Peek(sniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return false, err
+	}
+	for _, b := range head {
+		if b == 0 {
+			return false, nil
+		}
+	}
+	// the sniffed chunk may end in the middle of a multi-byte character
+	for i := 0; i < utf8.UTFMax && len(head) > 0 && !utf8.Valid(head); i++ {
+		head = head[:len(head)-1]
+	}
+	return utf8.Valid(head), nil
+}
+
+// archiveMemberExists returns true if the archive path points to an existing archive member. For zip archives, only the central directory is read.
+func archiveMemberExists(archive, member string) bool {
+	member = cleanMemberName(member)
+	if strings.HasSuffix(archive, ".zip") {
+		fh, err := os.Open(filepath.Clean(archive))
+		if err != nil {
+			return false
+		}
+		defer fh.Close()
+		zr, err := newZipReader(fh, archive)
+		if err != nil {
+			return false
+		}
+		for _, f := range zr.File {
+			if f.Mode().IsRegular() && cleanMemberName(f.Name) == member {
+				return true
+			}
+		}
+		return false
+	}
+	found := false
+	err := walkArchive(archive, func(name string, r io.Reader) (bool, error) {
+		found = name == member
+		return found, nil
+	})
+	return err == nil && found
+}
@@ -0,0 +1,171 @@
+package io
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type testMember struct {
+	name    string
+	content string
+}
+
+var testMembers = []testMember{
+	{"data/lex.tsv", "orth\ttrans\nhej\th E j\n"},
+	{"image.bin", "\x00\x01\x02"},
+	{"readme.txt", "räksmörgås\n"},
+}
+
+func writeTestZip(t *testing.T, fName string) {
+	fh, err := os.Create(fName)
+	if err != nil {
+		t.Fatalf("Couldn't create test file: %v", err)
+	}
+	defer fh.Close()
+	zw := zip.NewWriter(fh)
+	for _, m := range testMembers {
+		w, err := zw.Create(m.name)
+		if err != nil {
+			t.Fatalf("Couldn't create zip member: %v", err)
+		}
+		if _, err := w.Write([]byte(m.content)); err != nil {
+			t.Fatalf("Couldn't write zip member: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Couldn't close zip writer: %v", err)
+	}
+}
+
+func writeTestTarGz(t *testing.T, fName string) {
+	fh, err := os.Create(fName)
+	if err != nil {
+		t.Fatalf("Couldn't create test file: %v", err)
+	}
+	defer fh.Close()
+	gz := gzip.NewWriter(fh)
+	tw := tar.NewWriter(gz)
+	for _, m := range testMembers {
+		hdr := &tar.Header{Name: "./" + m.name, Mode: 0600, Size: int64(len(m.content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("Couldn't write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(m.content)); err != nil {
+			t.Fatalf("Couldn't write tar member: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Couldn't close tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Couldn't close gz writer: %v", err)
+	}
+}
+
+func TestSplitArchivePath(t *testing.T) {
+	var test = func(in, expArchive, expMember string, expOK bool) {
+		archive, member, ok := SplitArchivePath(in)
+		if archive != expArchive || member != expMember || ok != expOK {
+			t.Errorf(fsExpGot, []any{expArchive, expMember, expOK}, []any{archive, member, ok})
+		}
+	}
+	test("bundle.zip!data/lex.tsv", "bundle.zip", "data/lex.tsv", true)
+	test("/tmp/bundle.tar.gz!lex.tsv", "/tmp/bundle.tar.gz", "lex.tsv", true)
+	test("bundle.tgz!lex.tsv", "bundle.tgz", "lex.tsv", true)
+	test("bundle.zip!", "", "", false)
+	test("hello!", "", "", false)
+	test("lex.tsv", "", "", false)
+}
+
+func TestArchiveMembers(t *testing.T) {
+	dir := t.TempDir()
+	for _, archive := range []string{filepath.Join(dir, "bundle.zip"), filepath.Join(dir, "bundle.tar.gz")} {
+		if filepath.Ext(archive) == ".zip" {
+			writeTestZip(t, archive)
+		} else {
+			writeTestTarGz(t, archive)
+		}
+
+		all, err := ListArchiveMembers(archive)
+		if err != nil {
+			t.Errorf("Got error from ListArchiveMembers: %v", err)
+		}
+		exp := []string{"data/lex.tsv", "image.bin", "readme.txt"}
+		if !reflect.DeepEqual(all, exp) {
+			t.Errorf(fsExpGot, exp, all)
+		}
+
+		text, err := ListArchiveTextMembers(archive)
+		if err != nil {
+			t.Errorf("Got error from ListArchiveTextMembers: %v", err)
+		}
+		exp = []string{"data/lex.tsv", "readme.txt"}
+		if !reflect.DeepEqual(text, exp) {
+			t.Errorf(fsExpGot, exp, text)
+		}
+
+		fName := archive + "!data/lex.tsv"
+		if !IsFile(fName) {
+			t.Errorf("Expected IsFile to be true for %s", fName)
+		}
+		if IsFile(archive + "!nonexisting.tsv") {
+			t.Errorf("Expected IsFile to be false for non-existing member")
+		}
+
+		r, fh, err := GetFileReader(fName)
+		if err != nil {
+			t.Errorf("Got error from GetFileReader: %v", err)
+			continue
+		}
+		lines := []string{}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		fh.Close()
+		exp = []string{"orth\ttrans", "hej\th E j"}
+		if !reflect.DeepEqual(lines, exp) {
+			t.Errorf(fsExpGot, exp, lines)
+		}
+
+		lines, err = ReadFileToLines(fName)
+		if err != nil {
+			t.Errorf("Got error from ReadFileToLines: %v", err)
+		}
+		if !reflect.DeepEqual(lines, exp) {
+			t.Errorf(fsExpGot, exp, lines)
+		}
+	}
+}
+
+func TestOpenFileReaderClosesMember(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "bundle.zip")
+	writeTestZip(t, archive)
+	r, closer, err := OpenFileReader(archive + "!data/lex.tsv")
+	if err != nil {
+		t.Fatalf("Got error from OpenFileReader: %v", err)
+	}
+	buf := make([]byte, 4)
+	if _, err := r.Read(buf); err != nil {
+		t.Fatalf("Got error from Read: %v", err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Errorf("Got error from Close: %v", err)
+	}
+	// the zip member reader is closed along with the archive
+	if _, err := r.Read(buf); err == nil {
+		t.Errorf("Expected error reading from closed member")
+	}
+
+	_, closer, err = OpenFileReader(archive + "!nonexisting.tsv")
+	if err == nil {
+		t.Errorf("Expected error for non-existing member")
+	}
+	closer.Close()
+}
@@ -0,0 +1,211 @@
+package io
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkPolicy defines how symbolic links are handled when walking a directory tree
+type SymlinkPolicy int
+
+const (
+	// FollowFileSymlinks includes symlinks to files, but does not descend into symlinked directories (default)
+	FollowFileSymlinks SymlinkPolicy = iota
+	// FollowAllSymlinks includes symlinks to files, and descends into symlinked directories (symlink cycles are detected and skipped)
+	FollowAllSymlinks
+	// SkipSymlinks ignores all symlinks
+	SkipSymlinks
+)
+
+// FindOptions holds settings for Walk and Find
+type FindOptions struct {
+	// Include holds glob patterns for files to include (if empty, all files are included). Patterns without a slash are matched against the file's base name; patterns with a slash are matched against the path relative to the root, where ** matches any number of directories, e.g. data/**/*.tsv.
+	Include []string
+	// Exclude holds glob patterns (same syntax as Include) for files and directories to exclude. Excluded directories are not descended into.
+	Exclude []string
+	// Extensions holds file extensions to include, with or without a leading dot, e.g. "tsv" or ".tsv.gz" (if empty, all extensions are included)
+	Extensions []string
+	// Symlinks defines how symbolic links are handled
+	Symlinks SymlinkPolicy
+	// IncludeHidden includes files and directories with names starting with a dot
+	IncludeHidden bool
+}
+
+func (opts FindOptions) validate() error {
+	for _, p := range append(append([]string{}, opts.Include...), opts.Exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %s : %v", p, err)
+		}
+	}
+	return nil
+}
+
+func (opts FindOptions) excluded(relPath string) bool {
+	for _, p := range opts.Exclude {
+		if matchGlob(p, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (opts FindOptions) included(relPath string) bool {
+	if len(opts.Extensions) > 0 {
+		found := false
+		for _, ext := range opts.Extensions {
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			if strings.HasSuffix(relPath, ext) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(opts.Include) == 0 {
+		return true
+	}
+	for _, p := range opts.Include {
+		if matchGlob(p, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash separated relative path against a glob pattern (see FindOptions.Include)
+func matchGlob(pattern, relPath string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(relPath))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+func matchSegments(patterns, segments []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(patterns[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(patterns[0], segments[0]); !ok {
+			return false
+		}
+		patterns, segments = patterns[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// Walk walks the file tree rooted at root, calling fn for each file matching the options. Files are visited in lexical order, so the output is deterministic. If root is a file, fn is called for root itself (if it matches the options).
+func Walk(root string, opts FindOptions, fn func(path string, info fs.FileInfo) error) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		if opts.included(filepath.Base(root)) {
+			return fn(root, info)
+		}
+		return nil
+	}
+	ancestors := map[string]bool{}
+	return walkDir(root, "", opts, ancestors, fn)
+}
+
+// walkDir walks the directory dir. The ancestors map holds the real paths of the directories on the current path, so that symlink cycles can be detected; a directory reached twice through different symlinks is walked each time.
+func walkDir(dir, relDir string, opts FindOptions, ancestors map[string]bool, fn func(path string, info fs.FileInfo) error) error {
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		if ancestors[real] {
+			return nil
+		}
+		ancestors[real] = true
+		defer delete(ancestors, real)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("couldn't read directory %s : %v", dir, err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !opts.IncludeHidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+		p := filepath.Join(dir, name)
+		relPath := path.Join(relDir, name)
+		if opts.excluded(relPath) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if opts.Symlinks == SkipSymlinks {
+				continue
+			}
+			target, err := os.Stat(p)
+			if err != nil {
+				// dangling symlink
+				continue
+			}
+			if target.IsDir() && opts.Symlinks != FollowAllSymlinks {
+				continue
+			}
+			info = target
+		}
+		if info.IsDir() {
+			if err := walkDir(p, relPath, opts, ancestors, fn); err != nil {
+				return err
+			}
+		} else if info.Mode().IsRegular() && opts.included(relPath) {
+			if err := fn(p, info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Find returns the files in the file tree rooted at root matching the options, in lexical order
+func Find(root string, opts FindOptions) ([]string, error) {
+	res := []string{}
+	err := Walk(root, opts, func(path string, info fs.FileInfo) error {
+		res = append(res, path)
+		return nil
+	})
+	return res, err
+}
+
+// ExpandPaths expands directories in the input list into the files they contain (using Find), keeping the input order. Other paths (plain files, archive paths, etc) are kept as is.
+func ExpandPaths(paths []string, opts FindOptions) ([]string, error) {
+	res := []string{}
+	for _, p := range paths {
+		if isDir, err := IsDirectory(p); err == nil && isDir {
+			files, err := Find(p, opts)
+			if err != nil {
+				return res, err
+			}
+			res = append(res, files...)
+		} else {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
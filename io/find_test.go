@@ -0,0 +1,92 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func createTestTree(t *testing.T) string {
+	root := t.TempDir()
+	for _, f := range []string{"a.tsv", "b.txt", "data/c.tsv", "data/d.tsv.gz", "data/tmp/e.tsv", ".hidden/f.tsv", "data/.g.tsv"} {
+		p := filepath.Join(root, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatalf("Couldn't create test dir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(f+"\n"), 0600); err != nil {
+			t.Fatalf("Couldn't write test file: %v", err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(root, "a.tsv"), filepath.Join(root, "link.tsv")); err != nil {
+		t.Fatalf("Couldn't create symlink: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, "data"), filepath.Join(root, "linkdir")); err != nil {
+		t.Fatalf("Couldn't create symlink: %v", err)
+	}
+	// cycle
+	if err := os.Symlink(root, filepath.Join(root, "data", "loop")); err != nil {
+		t.Fatalf("Couldn't create symlink: %v", err)
+	}
+	return root
+}
+
+func TestMatchGlob(t *testing.T) {
+	var test = func(pattern, relPath string, exp bool) {
+		got := matchGlob(pattern, relPath)
+		if got != exp {
+			t.Errorf("%s %s "+fsExpGot, pattern, relPath, exp, got)
+		}
+	}
+	test("*.tsv", "data/c.tsv", true)
+	test("*.tsv", "data/c.txt", false)
+	test("data/*.tsv", "data/c.tsv", true)
+	test("data/*.tsv", "data/tmp/e.tsv", false)
+	test("data/**/*.tsv", "data/tmp/e.tsv", true)
+	test("data/**/*.tsv", "data/c.tsv", true)
+	test("**/tmp", "data/tmp", true)
+	test("**/tmp", "tmp", true)
+}
+
+func TestFind(t *testing.T) {
+	root := createTestTree(t)
+	var test = func(opts FindOptions, exp []string) {
+		got, err := Find(root, opts)
+		if err != nil {
+			t.Errorf("Got error from Find: %v", err)
+			return
+		}
+		for i, p := range got {
+			rel, _ := filepath.Rel(root, p)
+			got[i] = filepath.ToSlash(rel)
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test(FindOptions{}, []string{"a.tsv", "b.txt", "data/c.tsv", "data/d.tsv.gz", "data/tmp/e.tsv", "link.tsv"})
+	test(FindOptions{Extensions: []string{"tsv"}}, []string{"a.tsv", "data/c.tsv", "data/tmp/e.tsv", "link.tsv"})
+	test(FindOptions{Extensions: []string{".tsv.gz"}}, []string{"data/d.tsv.gz"})
+	test(FindOptions{Include: []string{"data/**/*.tsv"}}, []string{"data/c.tsv", "data/tmp/e.tsv"})
+	test(FindOptions{Extensions: []string{"tsv"}, Exclude: []string{"tmp", "link*"}}, []string{"a.tsv", "data/c.tsv"})
+	test(FindOptions{Symlinks: SkipSymlinks, Extensions: []string{"tsv"}}, []string{"a.tsv", "data/c.tsv", "data/tmp/e.tsv"})
+	test(FindOptions{IncludeHidden: true, Extensions: []string{"tsv"}, Exclude: []string{"tmp"}}, []string{".hidden/f.tsv", "a.tsv", "data/.g.tsv", "data/c.tsv", "link.tsv"})
+	// linkdir points at a sibling directory, which is not a cycle, whereas data/loop (and linkdir/loop) points back at the root
+	test(FindOptions{Symlinks: FollowAllSymlinks, Extensions: []string{"tsv"}}, []string{"a.tsv", "data/c.tsv", "data/tmp/e.tsv", "link.tsv", "linkdir/c.tsv", "linkdir/tmp/e.tsv"})
+
+	if _, err := Find(root, FindOptions{Include: []string{"[a-"}}); err == nil {
+		t.Errorf("Expected error for invalid pattern")
+	}
+}
+
+func TestExpandPaths(t *testing.T) {
+	root := createTestTree(t)
+	got, err := ExpandPaths([]string{filepath.Join(root, "b.txt"), filepath.Join(root, "data")}, FindOptions{Exclude: []string{"tmp"}})
+	if err != nil {
+		t.Errorf("Got error from ExpandPaths: %v", err)
+	}
+	exp := []string{filepath.Join(root, "b.txt"), filepath.Join(root, "data", "c.tsv"), filepath.Join(root, "data", "d.tsv.gz")}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
@@ -0,0 +1,129 @@
+package io
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is the default interval for checking a followed file for new data
+const DefaultPollInterval = 250 * time.Millisecond
+
+// FollowOptions holds settings for following a growing file (tail -f)
+type FollowOptions struct {
+	// PollInterval is the interval for checking the file for new data (if zero, DefaultPollInterval is used)
+	PollInterval time.Duration
+	// FromStart reads the existing contents of the file before following it; by default, only data appended after the file was opened is read
+	FromStart bool
+}
+
+// Follower is an io.ReadCloser that follows a file that is being appended to, such as a log file, similar to tail -F. When the end of the file is reached, Read waits for new data instead of returning io.EOF. If the file is truncated, reading restarts from the beginning; if the file is replaced (log rotation), the new file is opened and read from the beginning.
+// Read returns io.EOF once the Follower has been closed, or the context has been cancelled.
+type Follower struct {
+	ctx      context.Context
+	fName    string
+	opts     FollowOptions
+	fh       *os.File
+	mutex    sync.Mutex
+	closed   chan struct{}
+	isClosed bool
+}
+
+// Follow opens a file for following (see Follower). The context can be used to stop following the file.
+func Follow(ctx context.Context, fName string, opts FollowOptions) (*Follower, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	fh, err := os.Open(filepath.Clean(fName))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open file %s for reading : %v", fName, err)
+	}
+	if !opts.FromStart {
+		if _, err := fh.Seek(0, io.SeekEnd); err != nil {
+			fh.Close()
+			return nil, err
+		}
+	}
+	return &Follower{ctx: ctx, fName: fName, opts: opts, fh: fh, closed: make(chan struct{})}, nil
+}
+
+// Read reads new data from the followed file, waiting for data to be appended if needed
+func (f *Follower) Read(p []byte) (int, error) {
+	for {
+		f.mutex.Lock()
+		if f.isClosed {
+			f.mutex.Unlock()
+			return 0, io.EOF
+		}
+		n, err := f.fh.Read(p)
+		if n > 0 || (err != nil && err != io.EOF) {
+			f.mutex.Unlock()
+			return n, err
+		}
+		err = f.checkRotation()
+		f.mutex.Unlock()
+		if err != nil {
+			return 0, err
+		}
+
+		select {
+		case <-f.ctx.Done():
+			return 0, io.EOF
+		case <-f.closed:
+			return 0, io.EOF
+		case <-time.After(f.opts.PollInterval):
+		}
+	}
+}
+
+// checkRotation checks if the file has been truncated or replaced, and reopens/rewinds it if needed. It is called at end of file, with the mutex locked.
+func (f *Follower) checkRotation() error {
+	current, err := f.fh.Stat()
+	if err != nil {
+		return err
+	}
+	onDisk, err := os.Stat(f.fName)
+	if err != nil {
+		// the file may be temporarily missing during rotation
+		return nil
+	}
+	offset, err := f.fh.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if !os.SameFile(current, onDisk) {
+		if current.Size() > offset {
+			// data was appended to the old file before it was replaced
+			return nil
+		}
+		fh, err := os.Open(filepath.Clean(f.fName))
+		if err != nil {
+			return nil
+		}
+		f.fh.Close()
+		f.fh = fh
+		return nil
+	}
+	if current.Size() < offset {
+		if _, err := f.fh.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops following the file; any pending or subsequent Read returns io.EOF
+func (f *Follower) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.isClosed {
+		return nil
+	}
+	f.isClosed = true
+	close(f.closed)
+	return f.fh.Close()
+}
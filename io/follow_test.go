@@ -0,0 +1,107 @@
+package io
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFollow(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "server.log")
+	if err := os.WriteFile(fName, []byte("old line\n"), 0600); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	f, err := Follow(ctx, fName, FollowOptions{PollInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Got error from Follow: %v", err)
+	}
+	defer f.Close()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	var appendLine = func(s string) {
+		fh, err := os.OpenFile(fName, os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			t.Fatalf("Couldn't open test file: %v", err)
+		}
+		defer fh.Close()
+		if _, err := fh.WriteString(s + "\n"); err != nil {
+			t.Fatalf("Couldn't write test file: %v", err)
+		}
+	}
+	// waitFor polls until the condition holds, failing the test after a deadline
+	var waitFor = func(cond func() bool, what string) {
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timeout waiting for %s", what)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	var offset = func() int64 {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		pos, err := f.fh.Seek(0, io.SeekCurrent)
+		if err != nil {
+			t.Fatalf("Couldn't get file offset: %v", err)
+		}
+		return pos
+	}
+	var expect = func(exp string) {
+		select {
+		case got := <-lines:
+			if got != exp {
+				t.Errorf(fsExpGot, exp, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timeout waiting for line %s", exp)
+		}
+	}
+
+	appendLine("new line 1")
+	expect("new line 1")
+	appendLine("new line 2")
+	expect("new line 2")
+
+	// truncation
+	if err := os.WriteFile(fName, []byte{}, 0600); err != nil {
+		t.Fatalf("Couldn't truncate test file: %v", err)
+	}
+	waitFor(func() bool { return offset() == 0 }, "truncation to be detected")
+	appendLine("after truncation")
+	expect("after truncation")
+
+	// rotation
+	if err := os.Rename(fName, fName+".1"); err != nil {
+		t.Fatalf("Couldn't rename test file: %v", err)
+	}
+	if err := os.WriteFile(fName, []byte("after rotation\n"), 0600); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	expect("after rotation")
+
+	f.Close()
+	select {
+	case _, ok := <-lines:
+		if ok {
+			t.Errorf("Expected no more lines after Close")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for reader to stop after Close")
+	}
+}
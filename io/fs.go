@@ -0,0 +1,101 @@
+package io
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OSFS is an fs.FS that opens files in the operating system's file system. Unlike os.DirFS, it accepts any path that os.Open accepts (absolute paths, relative paths with ../, etc), so that command line arguments can be used as is. It is mainly intended for running code written against fs.FS on real files; for testing, use an fs.FS such as fstest.MapFS.
+// The FS variants of the read helpers fall back on the plain OS helpers for OSFS, so that archive paths (see GetFileReader) are supported.
+type OSFS struct{}
+
+// Open opens the named file using os.Open
+func (OSFS) Open(name string) (fs.File, error) {
+	return os.Open(filepath.Clean(name))
+}
+
+// Stat returns file info using os.Stat
+func (OSFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(filepath.Clean(name))
+}
+
+// ReadFile reads the named file using os.ReadFile
+func (OSFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Clean(name))
+}
+
+// IsDirectoryFS returns true if the given path is a directory in the file system fsys
+func IsDirectoryFS(fsys fs.FS, path string) (bool, error) {
+	fileInfo, err := fs.Stat(fsys, path)
+	if err != nil {
+		return false, err
+	}
+	return fileInfo.IsDir(), nil
+}
+
+// IsFileFS returns true if the given file exists in the file system fsys (as a file or as a directory)
+func IsFileFS(fsys fs.FS, fName string) bool {
+	if _, isOS := fsys.(OSFS); isOS {
+		return IsFile(fName)
+	}
+	if _, err := fs.Stat(fsys, fName); errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+	return true
+}
+
+// ReadFileToStringFS Read a file from the file system fsys into a string (keeping final newline, if any)
+func ReadFileToStringFS(fsys fs.FS, fName string) (string, error) {
+	if _, isOS := fsys.(OSFS); isOS {
+		return ReadFileToString(fName)
+	}
+	b, err := fs.ReadFile(fsys, fName)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ReadFileToLinesFS Read a file from the file system fsys into a list of lines
+func ReadFileToLinesFS(fsys fs.FS, fName string) ([]string, error) {
+	s, err := ReadFileToStringFS(fsys, fName)
+	if err != nil {
+		return []string{}, err
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n"), nil
+}
+
+// ReadFileToLinesWithOptionsFS Read a file from the file system fsys into a list of lines, stripping BOM and/or normalising line endings according to the options
+func ReadFileToLinesWithOptionsFS(fsys fs.FS, fName string, opts ReadOptions) ([]string, error) {
+	s, err := ReadFileToStringFS(fsys, fName)
+	if err != nil {
+		return []string{}, err
+	}
+	return strings.Split(strings.TrimSuffix(opts.apply(s), "\n"), "\n"), nil
+}
+
+// OpenFileReaderFS reads an input file from the file system fsys, gzipped or plain text, and returns an io.Reader for line scanning, along with a closer, that needs to be closed after reading (see OpenFileReader).
+func OpenFileReaderFS(fsys fs.FS, fName string) (io.Reader, io.Closer, error) {
+	if _, isOS := fsys.(OSFS); isOS {
+		return OpenFileReader(fName)
+	}
+	fh, err := fsys.Open(fName)
+	if err != nil {
+		return nil, multiCloser{}, fmt.Errorf("couldn't open file %s for reading : %v", fName, err)
+	}
+
+	if strings.HasSuffix(fName, ".gz") {
+		gz, err := gzip.NewReader(fh)
+		if err != nil {
+			return nil, fh, fmt.Errorf("couldn't to open gz reader : %v", err)
+		}
+		return io.Reader(gz), fh, nil
+	}
+	return io.Reader(fh), fh, nil
+}
@@ -46,8 +46,40 @@ func ReadFileToLines(fName string) ([]string, error) {
 	return strings.Split(strings.TrimSuffix(s, "\n"), "\n"), nil
 }
 
+// OpenFileReader works as GetFileReader, but returns a closer instead of the file handle. For archive members, the closer closes both the member reader and the archive file.
+func OpenFileReader(fName string) (io.Reader, io.Closer, error) {
+	if isArchivePath(fName) {
+		archive, member, _ := SplitArchivePath(fName)
+		r, fh, closer, err := getArchiveMemberReader(archive, member)
+		if fh == nil {
+			return r, multiCloser{}, err
+		}
+		if closer == nil {
+			return r, fh, err
+		}
+		return r, multiCloser{closer, fh}, err
+	}
+	r, fh, err := GetFileReader(fName)
+	if fh == nil {
+		return r, multiCloser{}, err
+	}
+	return r, fh, err
+}
+
 // ReadFileToString Read a file into a string using ioutil.ReadFile (keeping final newline, if any)
 func ReadFileToString(fName string) (string, error) {
+	if isArchivePath(fName) {
+		r, closer, err := OpenFileReader(fName)
+		defer closer.Close()
+		if err != nil {
+			return "", err
+		}
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
 	b, err := ioutil.ReadFile(filepath.Clean(fName))
 	if err != nil {
 		return "", err
@@ -74,8 +106,12 @@ func ReadStdinToLines() ([]string, error) {
 	return strings.Split(strings.TrimSuffix(s, "\n"), "\n"), nil
 }
 
-// IsFile returns true if the given file exists (as a file or as a directory)
+// IsFile returns true if the given file exists (as a file or as a directory, or as a member of an archive path such as bundle.zip!data/lex.tsv)
 func IsFile(fName string) bool {
+	if isArchivePath(fName) {
+		archive, member, _ := SplitArchivePath(fName)
+		return archiveMemberExists(archive, member)
+	}
 	if _, err := os.Stat(fName); os.IsNotExist(err) {
 		return false
 	}
@@ -83,7 +119,16 @@ func IsFile(fName string) bool {
 }
 
 // GetFileReader reads an input file, gzipped or plain text, and returns an io.Reader for line scanning, along with the file handle, that needs to be closed after reading.
+// Members of zip and tar archives can be read using archive paths, such as bundle.zip!data/lex.tsv or bundle.tar.gz!lex.tsv. For archive members, the returned file handle is the archive file, and the member reader is only closed when it has been read to the end, so callers that may stop reading early must use OpenFileReader instead, which returns a closer for both.
 func GetFileReader(fName string) (io.Reader, *os.File, error) {
+	if isArchivePath(fName) {
+		archive, member, _ := SplitArchivePath(fName)
+		r, fh, closer, err := getArchiveMemberReader(archive, member)
+		if err != nil {
+			return nil, fh, err
+		}
+		return &closeOnEOFReader{r: r, closer: closer}, fh, nil
+	}
 	fh, err := os.Open(filepath.Clean(fName))
 	//defer fh.Close()
 	if err != nil {
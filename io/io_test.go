@@ -1,7 +1,12 @@
 package io
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 var fsExpGot = "expected: %#v ; got: %#v"
@@ -20,3 +25,119 @@ func TestDetachFileExtension(t *testing.T) {
 		t.Errorf(fsExpGot, expectExt, resultExt)
 	}
 }
+
+func TestNormaliseLineEndings(t *testing.T) {
+	var test = func(in, exp string) {
+		got := NormaliseLineEndings(in)
+		if got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("a\nb\n", "a\nb\n")
+	test("a\r\nb\r\n", "a\nb\n")
+	test("a\rb\r", "a\nb\n")
+	test("a\r\n\rb", "a\n\nb")
+}
+
+func TestDetectLineEnding(t *testing.T) {
+	var test = func(in string, exp LineEnding) {
+		got := DetectLineEnding(in)
+		if got != exp {
+			t.Errorf(fsExpGot, exp.String(), got.String())
+		}
+	}
+	test("", NoLineEnding)
+	test("a", NoLineEnding)
+	test("a\nb\n", LF)
+	test("a\r\nb\r\n", CRLF)
+	test("a\rb", CR)
+	test("a\r\nb\n", MixedLineEndings)
+}
+
+func TestNormalisingReader(t *testing.T) {
+	var test = func(in string, opts ReadOptions, exp string) {
+		bts, err := ioutil.ReadAll(NewNormalisingReader(strings.NewReader(in), opts))
+		if err != nil {
+			t.Errorf("Got error from NewNormalisingReader: %v", err)
+			return
+		}
+		got := string(bts)
+		if got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test(BOM+"name\tvalue\r\na\tb\r\n", TextReadOptions, "name\tvalue\na\tb\n")
+	test(BOM+"name\r\n", ReadOptions{StripBOM: true}, "name\r\n")
+	test(BOM+"name\r\n", ReadOptions{NormaliseLineEndings: true}, BOM+"name\n")
+	test("a\rb\r", TextReadOptions, "a\nb\n")
+	test("a\r", TextReadOptions, "a\n")
+}
+
+func TestReadFileToLinesWithOptions(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "windows.txt")
+	err := ioutil.WriteFile(fName, []byte(BOM+"name\tvalue\r\na\tb\r\n"), 0600)
+	if err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+
+	exp := []string{"name\tvalue", "a\tb"}
+	got, err := ReadFileToLinesWithOptions(fName, TextReadOptions)
+	if err != nil {
+		t.Errorf("Got error from ReadFileToLinesWithOptions: %v", err)
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	le, err := FileLineEnding(fName)
+	if err != nil {
+		t.Errorf("Got error from FileLineEnding: %v", err)
+	}
+	if le != CRLF {
+		t.Errorf(fsExpGot, CRLF.String(), le.String())
+	}
+}
+
+func TestFSHelpers(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dir/lex.tsv": {Data: []byte("a\tb\nc\td\n")},
+	}
+
+	exp := []string{"a\tb", "c\td"}
+	got, err := ReadFileToLinesFS(fsys, "dir/lex.tsv")
+	if err != nil {
+		t.Errorf("Got error from ReadFileToLinesFS: %v", err)
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	if !IsFileFS(fsys, "dir/lex.tsv") {
+		t.Errorf("Expected IsFileFS to be true for dir/lex.tsv")
+	}
+	if IsFileFS(fsys, "dir/other.tsv") {
+		t.Errorf("Expected IsFileFS to be false for dir/other.tsv")
+	}
+	if isDir, err := IsDirectoryFS(fsys, "dir"); err != nil || !isDir {
+		t.Errorf("Expected IsDirectoryFS to be true for dir: %v", err)
+	}
+
+	r, closer, err := OpenFileReaderFS(fsys, "dir/lex.tsv")
+	if err != nil {
+		t.Fatalf("Got error from OpenFileReaderFS: %v", err)
+	}
+	defer closer.Close()
+	bts, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Errorf("Got error from reader: %v", err)
+	}
+	if string(bts) != "a\tb\nc\td\n" {
+		t.Errorf(fsExpGot, "a\tb\nc\td\n", string(bts))
+	}
+
+	_, closer, err = OpenFileReaderFS(fsys, "nonexisting")
+	if err == nil {
+		t.Errorf("Expected error from OpenFileReaderFS for non-existing file")
+	}
+	closer.Close()
+}
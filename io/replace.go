@@ -0,0 +1,85 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ReplaceFile rewrites a file atomically: the new contents are written by the write function to a temporary file in the same directory, which is then renamed to replace the original file. The original file mode is preserved. If backupSuffix is non-empty, the original file is kept as fName+backupSuffix (an existing backup file is overwritten). If fName is a symlink, the target file is replaced, and the symlink is kept. If the write function returns an error, the original file is left untouched.
+func ReplaceFile(fName string, backupSuffix string, write func(w io.Writer) error) error {
+	target, err := filepath.EvalSymlinks(fName)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("not a regular file: %s", fName)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("couldn't create temporary file for %s : %v", fName, err)
+	}
+	// the temporary file is removed on failure (after a successful rename, there is nothing to remove)
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := bufio.NewWriter(tmp)
+	if err := write(w); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if backupSuffix != "" {
+		if err := backup(target, fName+backupSuffix); err != nil {
+			return fmt.Errorf("couldn't create backup file for %s : %v", fName, err)
+		}
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// backup creates a backup copy of a file, as a hard link if possible
+func backup(src, dest string) error {
+	if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Link(src, dest); err == nil {
+		return nil
+	}
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(filepath.Clean(dest), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
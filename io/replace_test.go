@@ -0,0 +1,67 @@
+package io
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplaceFile(t *testing.T) {
+	dir := t.TempDir()
+	fName := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(fName, []byte("old"), 0751); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	link := filepath.Join(dir, "link.txt")
+	if err := os.Symlink("file.txt", link); err != nil {
+		t.Fatalf("Couldn't create symlink: %v", err)
+	}
+	var readFile = func(f string) string {
+		bts, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("Couldn't read file: %v", err)
+		}
+		return string(bts)
+	}
+
+	err := ReplaceFile(link, ".bak", func(w io.Writer) error {
+		_, err := io.WriteString(w, "new")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Got error from ReplaceFile: %v", err)
+	}
+	if exp, got := "new", readFile(fName); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := "old", readFile(link+".bak"); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected symlink to be kept")
+	}
+	if info, err := os.Stat(fName); err != nil || info.Mode().Perm() != 0751 {
+		t.Errorf("Expected file mode to be kept, got %v", info.Mode())
+	}
+
+	// failed write: the file is untouched, and no temporary files are left
+	err = ReplaceFile(fName, "", func(w io.Writer) error {
+		io.WriteString(w, "broken")
+		return fmt.Errorf("write failed")
+	})
+	if err == nil {
+		t.Errorf("Expected error from ReplaceFile")
+	}
+	if exp, got := "new", readFile(fName); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Couldn't read dir: %v", err)
+	}
+	if exp, got := 3, len(entries); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
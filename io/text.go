@@ -0,0 +1,234 @@
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BOM is the UTF-8 encoded byte order mark
+const BOM = "\uFEFF"
+
+// LineEnding identifies the line terminator used in a text
+type LineEnding int
+
+const (
+	// NoLineEnding is reported for input without any line terminators
+	NoLineEnding LineEnding = iota
+	// LF is the unix style line ending (\n)
+	LF
+	// CRLF is the windows style line ending (\r\n)
+	CRLF
+	// CR is the old mac style line ending (\r)
+	CR
+	// MixedLineEndings is reported when more than one type of line ending is used
+	MixedLineEndings
+)
+
+func (le LineEnding) String() string {
+	switch le {
+	case NoLineEnding:
+		return "none"
+	case LF:
+		return "LF"
+	case CRLF:
+		return "CRLF"
+	case CR:
+		return "CR"
+	case MixedLineEndings:
+		return "mixed"
+	}
+	return fmt.Sprintf("LineEnding(%d)", int(le))
+}
+
+// ReadOptions holds settings for reading text input
+type ReadOptions struct {
+	// StripBOM removes a UTF-8 byte order mark at the start of the input
+	StripBOM bool
+	// NormaliseLineEndings converts CRLF and CR line endings to LF
+	NormaliseLineEndings bool
+}
+
+// TextReadOptions strips the byte order mark and normalises line endings, which is what you usually want for text files from unknown sources
+var TextReadOptions = ReadOptions{StripBOM: true, NormaliseLineEndings: true}
+
+// NormaliseLineEndings converts CRLF and CR line endings to LF
+func NormaliseLineEndings(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
+
+// StripBOM removes a UTF-8 byte order mark from the start of the input string, if any
+func StripBOM(s string) string {
+	return strings.TrimPrefix(s, BOM)
+}
+
+func (opts ReadOptions) apply(s string) string {
+	if opts.StripBOM {
+		s = StripBOM(s)
+	}
+	if opts.NormaliseLineEndings {
+		s = NormaliseLineEndings(s)
+	}
+	return s
+}
+
+// ReadFileToStringWithOptions Read a file into a string (keeping final newline, if any), stripping BOM and/or normalising line endings according to the options
+func ReadFileToStringWithOptions(fName string, opts ReadOptions) (string, error) {
+	s, err := ReadFileToString(fName)
+	if err != nil {
+		return "", err
+	}
+	return opts.apply(s), nil
+}
+
+// ReadFileToLinesWithOptions Read a file into a list of lines, stripping BOM and/or normalising line endings according to the options
+func ReadFileToLinesWithOptions(fName string, opts ReadOptions) ([]string, error) {
+	s, err := ReadFileToStringWithOptions(fName, opts)
+	if err != nil {
+		return []string{}, err
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n"), nil
+}
+
+// ReadStdinToLinesWithOptions Read stdin into a list of lines, stripping BOM and/or normalising line endings according to the options
+func ReadStdinToLinesWithOptions(opts ReadOptions) ([]string, error) {
+	s, err := ReadStdinToString()
+	if err != nil {
+		return []string{}, err
+	}
+	return strings.Split(strings.TrimSuffix(opts.apply(s), "\n"), "\n"), nil
+}
+
+type normalisingReader struct {
+	r       *bufio.Reader
+	opts    ReadOptions
+	started bool
+}
+
+func (n *normalisingReader) Read(p []byte) (int, error) {
+	if !n.started {
+		n.started = true
+		if n.opts.StripBOM {
+			if head, err := n.r.Peek(len(BOM)); err == nil && string(head) == BOM {
+				_, _ = n.r.Discard(len(BOM))
+			}
+		}
+	}
+	if !n.opts.NormaliseLineEndings {
+		return n.r.Read(p)
+	}
+	i := 0
+	for i < len(p) {
+		b, err := n.r.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				return i, nil
+			}
+			return i, err
+		}
+		if b == '\r' {
+			if next, err := n.r.Peek(1); err == nil && next[0] == '\n' {
+				_, _ = n.r.Discard(1)
+			}
+			b = '\n'
+		}
+		p[i] = b
+		i++
+		// return what we have rather than blocking on a slow source
+		if n.r.Buffered() == 0 {
+			break
+		}
+	}
+	return i, nil
+}
+
+// NewNormalisingReader wraps a reader, stripping BOM and/or normalising line endings according to the options
+func NewNormalisingReader(r io.Reader, opts ReadOptions) io.Reader {
+	return &normalisingReader{r: bufio.NewReader(r), opts: opts}
+}
+
+// OpenFileReaderWithOptions works as OpenFileReader, but the returned reader strips BOM and/or normalises line endings according to the options
+func OpenFileReaderWithOptions(fName string, opts ReadOptions) (io.Reader, io.Closer, error) {
+	r, closer, err := OpenFileReader(fName)
+	if err != nil {
+		return nil, closer, err
+	}
+	return NewNormalisingReader(r, opts), closer, nil
+}
+
+// DetectLineEnding reports which line ending is used in the input string
+func DetectLineEnding(s string) LineEnding {
+	return detectLineEnding([]byte(s))
+}
+
+func detectLineEnding(bts []byte) LineEnding {
+	var lf, crlf, cr int
+	for i := 0; i < len(bts); i++ {
+		switch bts[i] {
+		case '\n':
+			lf++
+		case '\r':
+			if i+1 < len(bts) && bts[i+1] == '\n' {
+				crlf++
+				i++
+			} else {
+				cr++
+			}
+		}
+	}
+	return classifyLineEndings(lf, crlf, cr)
+}
+
+func classifyLineEndings(lf, crlf, cr int) LineEnding {
+	res := NoLineEnding
+	n := 0
+	if lf > 0 {
+		res = LF
+		n++
+	}
+	if crlf > 0 {
+		res = CRLF
+		n++
+	}
+	if cr > 0 {
+		res = CR
+		n++
+	}
+	if n > 1 {
+		return MixedLineEndings
+	}
+	return res
+}
+
+// FileLineEnding reports which line ending is used in the input file (gzipped or plain text)
+func FileLineEnding(fName string) (LineEnding, error) {
+	r, closer, err := OpenFileReader(fName)
+	defer closer.Close()
+	if err != nil {
+		return NoLineEnding, err
+	}
+	var lf, crlf, cr int
+	br := bufio.NewReader(r)
+	for {
+		chunk, err := br.ReadBytes('\n')
+		if len(chunk) > 0 {
+			if bytes.HasSuffix(chunk, []byte("\r\n")) {
+				crlf++
+				chunk = chunk[:len(chunk)-2]
+			} else if chunk[len(chunk)-1] == '\n' {
+				lf++
+				chunk = chunk[:len(chunk)-1]
+			}
+			cr += bytes.Count(chunk, []byte("\r"))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return NoLineEnding, fmt.Errorf("couldn't read file %s : %v", fName, err)
+		}
+	}
+	return classifyLineEndings(lf, crlf, cr), nil
+}
@@ -1,29 +1,7 @@
 package main
 
-import (
-	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"strings"
-
-	"github.com/HannaLindgren/go-utils/scripts/lib"
-)
-
-func process(s string) string {
-	return strings.ToLower(s)
-}
+import "github.com/HannaLindgren/go-utils/tools/downcase"
 
 func main() {
-	cmdname := filepath.Base(os.Args[0])
-	if len(os.Args) > 1 && strings.HasPrefix(os.Args[1], "-h") {
-		fmt.Fprintf(os.Stderr, "Usage: %s <files>\n", cmdname)
-		fmt.Fprintf(os.Stderr, "       or\n")
-		fmt.Fprintf(os.Stderr, "       cat <file> | %s\n", cmdname)
-		os.Exit(1)
-	}
-	err := lib.ConvertAndPrintFromArgsOrStdin(process, os.Args[1:])
-	if err != nil {
-		log.Fatalf("%v", err)
-	}
+	downcase.Main()
 }
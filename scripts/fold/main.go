@@ -0,0 +1,7 @@
+package main
+
+import "github.com/HannaLindgren/go-utils/tools/fold"
+
+func main() {
+	fold.Main()
+}
@@ -0,0 +1,93 @@
+package lib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnSpec is a requested column: an index (starting at 1), or a header name
+type ColumnSpec struct {
+	// Index is the column index, starting at 1 (zero if the column is requested by name)
+	Index int
+	// Name is the column header name (empty if the column is requested by index)
+	Name string
+}
+
+func (c ColumnSpec) String() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return strconv.Itoa(c.Index)
+}
+
+// ParseColumnSpecs parses a comma separated list of columns, where each column is an index (starting at 1) or a header name, e.g. "1,3" or "orth,country"
+func ParseColumnSpecs(s string) ([]ColumnSpec, error) {
+	res := []ColumnSpec{}
+	for _, col := range strings.Split(s, ",") {
+		col = strings.TrimSpace(col)
+		if col == "" {
+			return res, fmt.Errorf("empty column in column list: %s", s)
+		}
+		if i, err := strconv.Atoi(col); err == nil {
+			if i < 1 {
+				return res, fmt.Errorf("invalid column index %d (index starts at 1)", i)
+			}
+			res = append(res, ColumnSpec{Index: i})
+		} else {
+			res = append(res, ColumnSpec{Name: col})
+		}
+	}
+	return res, nil
+}
+
+// NeedsHeader returns true if any of the columns is requested by header name
+func NeedsHeader(specs []ColumnSpec) bool {
+	for _, c := range specs {
+		if c.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveColumns returns the (zero based) field indices of the requested columns. Header names are looked up in the header fields; header may be nil if all columns are requested by index.
+func ResolveColumns(specs []ColumnSpec, header []string) (map[int]bool, error) {
+	res := map[int]bool{}
+	for _, c := range specs {
+		if c.Name == "" {
+			res[c.Index-1] = true
+			continue
+		}
+		if header == nil {
+			return res, fmt.Errorf("column %s requested by name, but there is no header line", c.Name)
+		}
+		found := false
+		for i, h := range header {
+			if h == c.Name {
+				res[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return res, fmt.Errorf("requested column '%s' does not exist in header", c.Name)
+		}
+	}
+	return res, nil
+}
+
+// ConvertColumns splits the record into fields using the separator, and applies the conversion function to the selected fields (zero based indices, see ResolveColumns). Other fields are left untouched, as are selected columns missing from the record.
+func ConvertColumns(convert convertFuncE, rec, sep string, cols map[int]bool) (string, error) {
+	fields := strings.Split(rec, sep)
+	for i, f := range fields {
+		if !cols[i] {
+			continue
+		}
+		res, err := convert(f)
+		if err != nil {
+			return "", fmt.Errorf("column %d: %v", i+1, err)
+		}
+		fields[i] = res
+	}
+	return strings.Join(fields, sep), nil
+}
@@ -0,0 +1,24 @@
+package lib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseColumnSpecs(t *testing.T) {
+	specs, err := ParseColumnSpecs("1, orth,3")
+	if err != nil {
+		t.Fatalf("Got error from ParseColumnSpecs: %v", err)
+	}
+	if exp, got := "[1 orth 3]", fmt.Sprint(specs); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if !NeedsHeader(specs) {
+		t.Errorf("Expected NeedsHeader to be true for %v", specs)
+	}
+	for _, s := range []string{"0", "1,,2", ""} {
+		if _, err := ParseColumnSpecs(s); err == nil {
+			t.Errorf("Expected error for column list %#v", s)
+		}
+	}
+}
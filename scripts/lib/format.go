@@ -0,0 +1,228 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Format is an output format for tabular data (see TableWriter)
+type Format int
+
+const (
+	// TextFormat is the command's default plain text output: tab separated values without a header (default)
+	TextFormat Format = iota
+	// TSVFormat is tab separated values with a header line
+	TSVFormat
+	// JSONFormat is a JSON array of objects, with one object per row
+	JSONFormat
+	// JSONLinesFormat is one JSON object per line
+	JSONLinesFormat
+	// MarkdownFormat is a Markdown table
+	MarkdownFormat
+)
+
+var formatNames = []string{"text", "tsv", "json", "jsonl", "md"}
+
+func (f Format) String() string {
+	if int(f) >= 0 && int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Set implements flag.Value
+func (f *Format) Set(s string) error {
+	for i, name := range formatNames {
+		if s == name {
+			*f = Format(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %s, expected one of: %s", s, strings.Join(formatNames, ", "))
+}
+
+// IsJSON returns true for the JSON based formats
+func (f Format) IsJSON() bool {
+	return f == JSONFormat || f == JSONLinesFormat
+}
+
+// TableWriter writes rows of values in one of the output formats. For the text based formats, slice values are joined into a single cell (using tab for TextFormat, and space for TSVFormat and MarkdownFormat); other values are formatted using fmt.Sprint. For the JSON formats, values are marshalled as is, except for NaN and infinite floats, which are written as null. Close must be called when done, to finish the output.
+type TableWriter struct {
+	w      io.Writer
+	format Format
+	header []string
+	// terminator ends each row in TextFormat and TSVFormat
+	terminator string
+	nRows      int
+	// rows are collected for Markdown output, to align the columns
+	rows [][]string
+}
+
+// NewTableWriter creates a table writer using the given format. The header holds the column names, used as header line or JSON object keys.
+func NewTableWriter(w io.Writer, format Format, header ...string) *TableWriter {
+	return &TableWriter{w: w, format: format, header: header, terminator: "\n"}
+}
+
+// SetTerminator sets the row terminator for TextFormat and TSVFormat (default newline), e.g. NUL for NUL separated records. Other formats always use newline.
+func (t *TableWriter) SetTerminator(terminator string) {
+	t.terminator = terminator
+}
+
+// Format returns the output format
+func (t *TableWriter) Format() Format {
+	return t.format
+}
+
+// Write writes a row. The number of values should match the header (except for TextFormat, where any number of values is allowed).
+func (t *TableWriter) Write(values ...any) error {
+	if t.format != TextFormat && len(values) != len(t.header) {
+		return fmt.Errorf("expected %d values, got %d: %v", len(t.header), len(values), values)
+	}
+	defer func() { t.nRows++ }()
+	switch t.format {
+	case TextFormat:
+		_, err := fmt.Fprint(t.w, strings.Join(t.cells(values, "\t"), "\t")+t.terminator)
+		return err
+	case TSVFormat:
+		if t.nRows == 0 {
+			if _, err := fmt.Fprint(t.w, strings.Join(escapeTSV(t.header), "\t")+t.terminator); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprint(t.w, strings.Join(escapeTSV(t.cells(values, " ")), "\t")+t.terminator)
+		return err
+	case JSONFormat, JSONLinesFormat:
+		obj, err := t.jsonObject(values)
+		if err != nil {
+			return err
+		}
+		if t.format == JSONLinesFormat {
+			_, err = fmt.Fprintln(t.w, obj)
+			return err
+		}
+		sep := ",\n"
+		if t.nRows == 0 {
+			sep = "[\n"
+		}
+		_, err = fmt.Fprint(t.w, sep+"  "+obj)
+		return err
+	case MarkdownFormat:
+		t.rows = append(t.rows, t.cells(values, " "))
+		return nil
+	}
+	return fmt.Errorf("unknown output format: %v", t.format)
+}
+
+// Close finishes the output (closing the JSON array, or printing the Markdown table). It does not close the underlying writer.
+func (t *TableWriter) Close() error {
+	switch t.format {
+	case JSONFormat:
+		if t.nRows == 0 {
+			_, err := fmt.Fprintln(t.w, "[]")
+			return err
+		}
+		_, err := fmt.Fprintln(t.w, "\n]")
+		return err
+	case MarkdownFormat:
+		return t.writeMarkdown()
+	}
+	return nil
+}
+
+func (t *TableWriter) cells(values []any, sliceSep string) []string {
+	res := []string{}
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			res = append(res, v)
+		case []string:
+			res = append(res, strings.Join(v, sliceSep))
+		default:
+			res = append(res, fmt.Sprint(v))
+		}
+	}
+	return res
+}
+
+func escapeTSV(cells []string) []string {
+	res := make([]string, len(cells))
+	r := strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+	for i, c := range cells {
+		res[i] = r.Replace(c)
+	}
+	return res
+}
+
+// jsonObject marshals a row into a JSON object, keeping the column order
+func (t *TableWriter) jsonObject(values []any) (string, error) {
+	parts := []string{}
+	for i, v := range values {
+		if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			v = nil
+		}
+		key, err := json.Marshal(t.header[i])
+		if err != nil {
+			return "", err
+		}
+		val, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("couldn't marshal value for %s : %v", t.header[i], err)
+		}
+		parts = append(parts, string(key)+":"+string(val))
+	}
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
+
+// markdownWidth returns the display width of a Markdown cell (see strings.Width), counting control characters (such as tab) as one column each, since they are printed as is
+func markdownWidth(s string) int {
+	n := str.Width(s)
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *TableWriter) writeMarkdown() error {
+	r := strings.NewReplacer("|", "\\|", "\n", " ", "\r", " ")
+	rows := append([][]string{append([]string{}, t.header...)}, t.rows...)
+	widths := make([]int, len(t.header))
+	for i := range widths {
+		// the header separator needs at least three dashes
+		widths[i] = 3
+	}
+	for _, row := range rows {
+		for i := range row {
+			row[i] = r.Replace(row[i])
+			if n := markdownWidth(row[i]); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	line := func(cells []string) error {
+		padded := []string{}
+		for i, c := range cells {
+			padded = append(padded, c+strings.Repeat(" ", max(0, widths[i]-markdownWidth(c))))
+		}
+		_, err := fmt.Fprintf(t.w, "| %s |\n", strings.Join(padded, " | "))
+		return err
+	}
+	sep := []string{}
+	for _, w := range widths {
+		sep = append(sep, strings.Repeat("-", w))
+	}
+	rows = append([][]string{rows[0], sep}, rows[1:]...)
+	for _, row := range rows {
+		if err := line(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
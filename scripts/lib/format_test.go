@@ -0,0 +1,74 @@
+package lib
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestTableWriter(t *testing.T) {
+	var test = func(format Format, exp string) {
+		buf := &bytes.Buffer{}
+		tw := NewTableWriter(buf, format, "name", "count", "parts")
+		if err := tw.Write("a|b", 2, []string{"a", "b"}); err != nil {
+			t.Errorf("Got error from Write: %v", err)
+		}
+		if err := tw.Write("x\ty", math.NaN(), []string{}); err != nil {
+			t.Errorf("Got error from Write: %v", err)
+		}
+		if err := tw.Close(); err != nil {
+			t.Errorf("Got error from Close: %v", err)
+		}
+		if got := buf.String(); got != exp {
+			t.Errorf("%v: "+fsExpGot, format, exp, got)
+		}
+	}
+	test(TextFormat, "a|b\t2\ta\tb\nx\ty\tNaN\t\n")
+	test(TSVFormat, "name\tcount\tparts\na|b\t2\ta b\nx\\ty\tNaN\t\n")
+	test(JSONFormat, `[
+  {"name":"a|b","count":2,"parts":["a","b"]},
+  {"name":"x\ty","count":null,"parts":[]}
+]
+`)
+	test(JSONLinesFormat, `{"name":"a|b","count":2,"parts":["a","b"]}
+{"name":"x\ty","count":null,"parts":[]}
+`)
+	test(MarkdownFormat, "| name | count | parts |\n| ---- | ----- | ----- |\n| a\\|b | 2     | a b   |\n| x\ty  | NaN   |       |\n")
+
+	// Markdown columns are aligned by display width
+	buf := &bytes.Buffer{}
+	tw := NewTableWriter(buf, MarkdownFormat, "word", "n")
+	for _, w := range []string{"日本語", "A\u030asa", "😀"} {
+		if err := tw.Write(w, 1); err != nil {
+			t.Errorf("Got error from Write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Errorf("Got error from Close: %v", err)
+	}
+	if exp, got := "| word   | n   |\n| ------ | --- |\n| 日本語 | 1   |\n| A\u030asa    | 1   |\n| 😀     | 1   |\n", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	buf = &bytes.Buffer{}
+	tw = NewTableWriter(buf, JSONFormat, "name")
+	if err := tw.Close(); err != nil {
+		t.Errorf("Got error from Close: %v", err)
+	}
+	if exp, got := "[]\n", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if err := NewTableWriter(buf, TSVFormat, "name").Write("a", "b"); err == nil {
+		t.Errorf("Expected error for wrong number of values")
+	}
+}
+
+func TestFormatFlag(t *testing.T) {
+	var f Format
+	if err := f.Set("jsonl"); err != nil || f != JSONLinesFormat {
+		t.Errorf("Expected jsonl format, got %v (%v)", f, err)
+	}
+	if err := f.Set("xml"); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
@@ -10,21 +10,36 @@ import (
 
 type convertFunc func(string) string
 
-// ConvertAndPrintFromFilesOrStdin
+type convertFuncE func(string) (string, error)
+
+// FindOptions are used when expanding directory arguments into the files they contain
+var FindOptions = io.FindOptions{}
+
+func convertAndPrintFile(convert convertFunc, f string) error {
+	r, closer, err := io.OpenFileReader(f)
+	defer closer.Close()
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		s := scanner.Text()
+		fmt.Println(convert(s))
+	}
+	return nil
+}
+
+// ConvertAndPrintFromFilesOrStdin takes a conversion function, and as conversion input it uses (1) files specified in args (directories are expanded recursively into the files they contain, see FindOptions); or (2) stdin.
 func ConvertAndPrintFromFilesOrStdin(convert convertFunc, files []string) error {
 	if len(files) > 0 {
+		files, err := io.ExpandPaths(files, FindOptions)
+		if err != nil {
+			return err
+		}
 		for _, f := range files {
-			r, fh, err := io.GetFileReader(f)
-			defer fh.Close()
-			if err != nil {
+			if err := convertAndPrintFile(convert, f); err != nil {
 				return err
 			}
-			scanner := bufio.NewScanner(r)
-			for scanner.Scan() {
-				s := scanner.Text()
-				fmt.Println(convert(s))
-			}
-
 		}
 	} else {
 		scanner := bufio.NewScanner(os.Stdin)
@@ -36,20 +51,23 @@ func ConvertAndPrintFromFilesOrStdin(convert convertFunc, files []string) error
 	return nil
 }
 
-// ConvertAndPrintFromArgsOrStdin takes a conversion function, and as conversion input it uses (1) files or strings specified in args; or (2) stdin. The conversion function should convert an input string to another (output) string. It's a utility for writing simple code for processing textfiles, typically converting each input line into another output line (upcase, line length, etc).
+// ConvertAndPrintFromArgsOrStdin takes a conversion function, and as conversion input it uses (1) files, directories or strings specified in args; or (2) stdin. Directories are expanded recursively into the files they contain (see FindOptions). The conversion function should convert an input string to another (output) string. It's a utility for writing simple code for processing textfiles, typically converting each input line into another output line (upcase, line length, etc).
 func ConvertAndPrintFromArgsOrStdin(convert convertFunc, args []string) error {
 	if len(args) > 0 {
 		for _, arg := range args {
-			if io.IsFile(arg) {
-				r, fh, err := io.GetFileReader(arg)
-				defer fh.Close()
+			if isDir, err := io.IsDirectory(arg); err == nil && isDir {
+				files, err := io.Find(arg, FindOptions)
 				if err != nil {
 					return err
 				}
-				scanner := bufio.NewScanner(r)
-				for scanner.Scan() {
-					s := scanner.Text()
-					fmt.Println(convert(s))
+				for _, f := range files {
+					if err := convertAndPrintFile(convert, f); err != nil {
+						return err
+					}
+				}
+			} else if io.IsFile(arg) {
+				if err := convertAndPrintFile(convert, arg); err != nil {
+					return err
 				}
 			} else {
 				fmt.Println(convert(arg))
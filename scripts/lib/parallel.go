@@ -0,0 +1,113 @@
+package lib
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// DefaultBatchSize is the default number of records sent to a worker at a time in parallel processing
+const DefaultBatchSize = 1000
+
+// ParallelOptions holds settings for ordered parallel processing (see ProcessOrdered)
+type ParallelOptions struct {
+	// Workers is the number of worker goroutines (if < 1, the number of CPUs is used)
+	Workers int
+	// BatchSize is the number of items sent to a worker at a time (if < 1, DefaultBatchSize is used)
+	BatchSize int
+}
+
+type batch[T any] struct {
+	items []T
+	done  chan struct{}
+}
+
+var errStopped = errors.New("stopped")
+
+// ProcessOrdered processes items concurrently using a pool of workers, keeping the input order. The produce function is called (in a separate goroutine) to generate the input items, passing each item to emit. Items are collected into batches, and each batch is processed by one of the workers, calling work for each item. The processed items are passed to consume in input order, in the calling goroutine. If consume returns an error, processing is stopped, and the error is returned. Memory usage is bounded: at most about 2 x Workers batches are in progress at a time.
+func ProcessOrdered[T any](opts ParallelOptions, produce func(emit func(T) error) error, work func(T) T, consume func(T) error) error {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+
+	todo := make(chan *batch[T], workers)
+	ordered := make(chan *batch[T], workers)
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range todo {
+				for i, item := range b.items {
+					b.items[i] = work(item)
+				}
+				close(b.done)
+			}
+		}()
+	}
+
+	var produceErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ordered)
+		defer close(todo)
+		send := func(b *batch[T]) error {
+			// the consumer must get the batch before the workers, to keep the ordering
+			select {
+			case ordered <- b:
+			case <-quit:
+				return errStopped
+			}
+			select {
+			case todo <- b:
+			case <-quit:
+				return errStopped
+			}
+			return nil
+		}
+		cur := &batch[T]{done: make(chan struct{})}
+		err := produce(func(item T) error {
+			cur.items = append(cur.items, item)
+			if len(cur.items) < batchSize {
+				return nil
+			}
+			b := cur
+			cur = &batch[T]{done: make(chan struct{})}
+			return send(b)
+		})
+		if err == nil && len(cur.items) > 0 {
+			err = send(cur)
+		}
+		if !errors.Is(err, errStopped) {
+			produceErr = err
+		}
+	}()
+
+	var err error
+	for b := range ordered {
+		if err != nil {
+			// stopped: drain the queue, so that the producer can finish
+			continue
+		}
+		<-b.done
+		for _, item := range b.items {
+			if err = consume(item); err != nil {
+				close(quit)
+				break
+			}
+		}
+	}
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	return produceErr
+}
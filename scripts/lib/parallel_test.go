@@ -0,0 +1,97 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProcessOrdered(t *testing.T) {
+	n := 1000
+	for _, opts := range []ParallelOptions{{Workers: 4, BatchSize: 7}, {Workers: 1, BatchSize: 1}, {}} {
+		produce := func(emit func(int) error) error {
+			for i := 0; i < n; i++ {
+				if err := emit(i); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		work := func(i int) int {
+			if i%3 == 0 {
+				// make some items slower, to shuffle the processing order
+				time.Sleep(time.Microsecond * 10)
+			}
+			return i * 2
+		}
+		got := []int{}
+		err := ProcessOrdered(opts, produce, work, func(i int) error {
+			got = append(got, i)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Got error from ProcessOrdered: %v", err)
+		}
+		if len(got) != n {
+			t.Errorf(fsExpGot, n, len(got))
+			continue
+		}
+		for i, v := range got {
+			if v != i*2 {
+				t.Errorf("Unexpected item at position %d, expected: %d ; got: %d", i, i*2, v)
+				break
+			}
+		}
+	}
+}
+
+func TestProcessOrderedStop(t *testing.T) {
+	produce := func(emit func(int) error) error {
+		for i := 0; ; i++ {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+	}
+	nConsumed := 0
+	err := ProcessOrdered(ParallelOptions{Workers: 3, BatchSize: 5}, produce, func(i int) int { return i }, func(i int) error {
+		nConsumed++
+		if i == 42 {
+			return fmt.Errorf("stop at %d", i)
+		}
+		return nil
+	})
+	if exp, got := "stop at 42", fmt.Sprint(err); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := 43, nConsumed; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestRunnerParallel(t *testing.T) {
+	lines := []string{}
+	for i := 0; i < 500; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	fName := writeTestFile(t, "input.txt", strings.Join(lines, "\n")+"\n")
+	r, buf := newTestRunner(t, []string{"-j", "4", "-batch", "10", fName})
+	if err := r.RunConverter(strings.ToUpper); err != nil {
+		t.Errorf("Got error from RunConverter: %v", err)
+	}
+	if exp, got := strings.ToUpper(strings.Join(lines, "\n"))+"\n", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	r, _ = newTestRunner(t, []string{"-j", "4", "-batch", "10", fName})
+	err := r.RunConverterE(func(s string) (string, error) {
+		if s == "line 123" {
+			return "", fmt.Errorf("bad line")
+		}
+		return s, nil
+	})
+	if exp, got := fName+":124: bad line", fmt.Sprint(err); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
@@ -0,0 +1,713 @@
+package lib
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	hio "github.com/HannaLindgren/go-utils/io"
+)
+
+// StdinName is the input name used for stdin
+const StdinName = "<stdin>"
+
+// InputMode defines how a command reads its input, which determines the standard flags registered by a Runner
+type InputMode int
+
+const (
+	// ConvertRecords is for line conversion commands, using RunConverter or RunConverterE (default): all standard flags are registered
+	ConvertRecords InputMode = iota
+	// ReadRecords is for commands reading records using ForEachRecord: flags -o, -f, -F and -z are registered
+	ReadRecords
+	// ReadInputs is for commands reading whole inputs using ForEachInput: flags -o, -f and -F are registered
+	ReadInputs
+)
+
+// Runner is a command line runner for the scripts, handling usage, input and output, and the standard flags:
+//
+//	-o <file>  write output to file instead of stdout (unless NoOutputFlag is set)
+//	-f         force file input: treat all arguments as files, instead of guessing between files and literal strings
+//	-F <file>  follow a growing file, such as a server log (similar to tail -F), until interrupted
+//	-z         NUL separated input and output records, instead of newline separated (record based commands only)
+//	-i         edit files in place (line conversion only)
+//	-b <suffix> backup suffix for -i: keep the original files, with the suffix appended to the file name
+//	-e <mode>  conversion error handling: stop, skip or pass (line conversion only, see ErrorPolicy)
+//	-cols <columns>  convert selected columns only: comma separated indices (starting at 1) or header names (line conversion only)
+//	-sep <separator> field separator for -cols (default tab)
+//	-j <workers>     number of parallel workers for line conversion (output order is kept)
+//	-batch <lines>   number of lines sent to a worker at a time, for -j
+//	--         all arguments after -- are literal strings, not files
+//
+// The flags marked as line conversion only are registered for line conversion commands only (see InputMode). Directory arguments are expanded recursively into the files they contain (see FindOptions).
+type Runner struct {
+	// Name is the command name, used in the usage message
+	Name string
+	// Description is a short description of the command, used in the usage message
+	Description string
+	// ParamNames are the names of required positional arguments preceding the input arguments, such as <fields-to-print>
+	ParamNames []string
+	// ArgsAreFiles is set for commands that only read files, so that input arguments (except after --) are never guessed to be literal strings
+	ArgsAreFiles bool
+	// Examples are example command lines, printed in the usage message
+	Examples []string
+	// Mode defines how the command reads its input, and thereby which standard flags are registered (see InputMode). It must be set before Parse.
+	Mode InputMode
+	// NoOutputFlag is set for commands that use -o for a command specific flag, so that the standard -o flag is not registered. It must be set before Parse.
+	NoOutputFlag bool
+
+	// Params holds the positional parameters (see ParamNames) after parsing
+	Params []string
+	// Out is the output writer (stdout, or the -o output file). Output is buffered, and flushed by the RunConverter/ForEach methods and by Close.
+	Out io.Writer
+	// Stdin is the reader used when there are no input arguments
+	Stdin io.Reader
+	// Errors is the writer for conversion errors that don't stop processing (see ErrorPolicy)
+	Errors io.Writer
+	// OnError is the conversion error policy, set by the -e flag
+	OnError ErrorPolicy
+
+	flags        *flag.FlagSet
+	registered   bool
+	output       string
+	forceFile    bool
+	follow       string
+	nulSeparated bool
+	inPlace      bool
+	backupSuffix string
+	columns      string
+	fieldSep     string
+	colSpecs     []ColumnSpec
+	workers      int
+	batchSize    int
+	format       Format
+	lang         string
+	langTag      language.Tag
+
+	inputs  []input
+	out     *bufio.Writer
+	outFile *os.File
+
+	// followCtx stops following the -F file; if nil, following stops on interrupt (SIGINT)
+	followCtx  context.Context
+	followOpts hio.FollowOptions
+}
+
+// ErrorPolicy defines how a Runner handles conversion errors (see RunConverterE)
+type ErrorPolicy int
+
+const (
+	// StopOnError stops processing at the first error (default)
+	StopOnError ErrorPolicy = iota
+	// SkipOnError reports the error to stderr, and drops the failing line from the output
+	SkipOnError
+	// PassOnError reports the error to stderr, and outputs the failing line unchanged
+	PassOnError
+)
+
+var errorPolicyNames = []string{"stop", "skip", "pass"}
+
+func (p ErrorPolicy) String() string {
+	if int(p) >= 0 && int(p) < len(errorPolicyNames) {
+		return errorPolicyNames[p]
+	}
+	return fmt.Sprintf("ErrorPolicy(%d)", int(p))
+}
+
+// Set implements flag.Value
+func (p *ErrorPolicy) Set(s string) error {
+	for i, name := range errorPolicyNames {
+		if s == name {
+			*p = ErrorPolicy(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid error policy %s, expected one of: %s", s, strings.Join(errorPolicyNames, ", "))
+}
+
+type input struct {
+	arg     string
+	literal bool
+}
+
+// Input is an input source for a Runner: a file, a literal string argument, or stdin
+type Input struct {
+	// Name is the file name, the literal string itself, or StdinName
+	Name string
+	// Literal is true for literal string arguments
+	Literal bool
+	// Reader holds the input data
+	Reader io.Reader
+}
+
+// Text reads the whole input into a string. For stdin, the final newline (if any) is removed, as in io.ReadStdinToString.
+func (in Input) Text() (string, error) {
+	if in.Literal {
+		return in.Name, nil
+	}
+	bts, err := io.ReadAll(in.Reader)
+	if err != nil {
+		return "", err
+	}
+	if in.Name == StdinName {
+		return strings.TrimSuffix(string(bts), "\n"), nil
+	}
+	return string(bts), nil
+}
+
+// NewRunner creates a runner using the command line flag set (flag.CommandLine), so that command specific flags can be defined using the flag package as usual. The description is printed in the usage message, along with the names of any required positional parameters.
+func NewRunner(description string, paramNames ...string) *Runner {
+	return newRunner(flag.CommandLine, filepath.Base(os.Args[0]), description, paramNames...)
+}
+
+func newRunner(flags *flag.FlagSet, name string, description string, paramNames ...string) *Runner {
+	r := &Runner{
+		Name:        name,
+		Description: description,
+		ParamNames:  paramNames,
+		Stdin:       os.Stdin,
+		Errors:      os.Stderr,
+		flags:       flags,
+	}
+	flags.Usage = r.PrintUsage
+	return r
+}
+
+// registerFlags registers the standard flags for the input mode, unless already registered
+func (r *Runner) registerFlags() {
+	if r.registered {
+		return
+	}
+	r.registered = true
+	flags := r.flags
+	if !r.NoOutputFlag {
+		flags.StringVar(&r.output, "o", "", "Write output to `file` instead of stdout")
+	}
+	flags.BoolVar(&r.forceFile, "f", false, "Force file input: treat all arguments as files, instead of guessing between files and literal strings")
+	flags.StringVar(&r.follow, "F", "", "Follow a growing `file`, such as a server log, reading data as it is appended (similar to tail -F), until interrupted (Ctrl-C)")
+	if r.Mode == ReadInputs {
+		return
+	}
+	flags.BoolVar(&r.nulSeparated, "z", false, "NUL separated input and output records, instead of newline separated")
+	if r.Mode == ReadRecords {
+		return
+	}
+	flags.BoolVar(&r.inPlace, "i", false, "Edit files in place; files are replaced atomically, keeping the file mode")
+	flags.StringVar(&r.backupSuffix, "b", "", "Backup `suffix` for -i: keep the original files, with the suffix appended to the file name (e.g. .bak)")
+	flags.Var(&r.OnError, "e", "Conversion error handling `mode`: stop, skip (report and drop the line) or pass (report and output the line unchanged)")
+	flags.StringVar(&r.columns, "cols", "", "Convert selected `columns` only: comma separated indices (starting at 1) or header names, other columns are left untouched")
+	flags.StringVar(&r.fieldSep, "sep", "<tab>", "Field `separator` for -cols")
+	flags.IntVar(&r.workers, "j", 1, "Number of parallel `workers` for line conversion (0 = number of CPUs); output order is kept")
+	flags.IntVar(&r.batchSize, "batch", DefaultBatchSize, "Number of `lines` sent to a worker at a time, for -j")
+}
+
+// PrintUsage prints the usage message to stderr
+func (r *Runner) PrintUsage() {
+	w := r.flags.Output()
+	params := ""
+	for _, p := range r.ParamNames {
+		params += " " + p
+	}
+	if r.Description != "" {
+		fmt.Fprintln(w, r.Description)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Usage: %s <flags>%s <files>\n", r.Name, params)
+	fmt.Fprintf(w, "       or\n")
+	if !r.ArgsAreFiles {
+		fmt.Fprintf(w, "       %s <flags>%s <strings>\n", r.Name, params)
+		fmt.Fprintf(w, "       or\n")
+	}
+	fmt.Fprintf(w, "       %s <flags>%s -- <literal strings>\n", r.Name, params)
+	fmt.Fprintf(w, "       or\n")
+	fmt.Fprintf(w, "       cat <file> | %s <flags>%s\n", r.Name, params)
+	fmt.Fprintln(w, "\nOptional flags:")
+	r.registerFlags()
+	r.flags.PrintDefaults()
+	if len(r.Examples) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Example usage:\n")
+		for _, ex := range r.Examples {
+			fmt.Fprintln(w, ex)
+		}
+	}
+}
+
+// Parse parses the command line arguments. On invalid arguments, the usage is printed, and the program exits.
+func (r *Runner) Parse() {
+	if err := r.parse(os.Args[1:]); err != nil {
+		fmt.Fprintf(r.flags.Output(), "[error] %v\n\n", err)
+		r.PrintUsage()
+		os.Exit(1)
+	}
+}
+
+// flagTerminator returns true if the flag arguments are terminated by --, which the flag package consumes. The arguments are scanned as the flag package does, so that -- as the value of a flag (as in -o --) is not taken for a terminator.
+func (r *Runner) flagTerminator(args []string) bool {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if len(a) < 2 || a[0] != '-' {
+			return false
+		}
+		if a == "--" {
+			return true
+		}
+		name := strings.TrimPrefix(a[1:], "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		f := r.flags.Lookup(name)
+		if f == nil {
+			return false
+		}
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+			continue
+		}
+		// the next argument is the flag value
+		i++
+	}
+	return false
+}
+
+func (r *Runner) parse(args []string) error {
+	r.registerFlags()
+	if err := r.flags.Parse(args); err != nil {
+		return err
+	}
+	rest := r.flags.Args()
+	literal := r.flagTerminator(args[:len(args)-len(rest)])
+	if len(rest) < len(r.ParamNames) {
+		return fmt.Errorf("missing required arguments: %s", strings.Join(r.ParamNames[len(rest):], " "))
+	}
+	r.Params = rest[:len(r.ParamNames)]
+	r.inputs = []input{}
+	for _, arg := range rest[len(r.ParamNames):] {
+		if !literal && arg == "--" {
+			literal = true
+			continue
+		}
+		r.inputs = append(r.inputs, input{arg: arg, literal: literal})
+	}
+
+	if r.follow != "" {
+		if len(r.inputs) > 0 {
+			return fmt.Errorf("flag -F cannot be combined with input arguments")
+		}
+		if r.inPlace {
+			return fmt.Errorf("flags -i and -F cannot be combined")
+		}
+	}
+	if r.backupSuffix != "" && !r.inPlace {
+		return fmt.Errorf("flag -b requires -i")
+	}
+	if r.inPlace {
+		if r.output != "" {
+			return fmt.Errorf("flags -i and -o cannot be combined")
+		}
+		if len(r.inputs) == 0 {
+			return fmt.Errorf("in-place editing requires input files")
+		}
+		for _, in := range r.inputs {
+			if in.literal {
+				return fmt.Errorf("in-place editing cannot be used with literal strings")
+			}
+		}
+	}
+
+	if r.nulSeparated && r.format != TextFormat && r.format != TSVFormat {
+		return fmt.Errorf("flag -z cannot be combined with -format %v", r.format)
+	}
+
+	if r.fieldSep == "<tab>" {
+		r.fieldSep = "\t"
+	}
+	if r.lang != "" {
+		tag, err := language.Parse(r.lang)
+		if err != nil {
+			return fmt.Errorf("invalid language %s : %v", r.lang, err)
+		}
+		r.langTag = tag
+	}
+	if r.columns != "" {
+		specs, err := ParseColumnSpecs(r.columns)
+		if err != nil {
+			return err
+		}
+		r.colSpecs = specs
+	}
+
+	var out io.Writer = os.Stdout
+	if r.output != "" {
+		fh, err := os.Create(filepath.Clean(r.output))
+		if err != nil {
+			return fmt.Errorf("couldn't create output file %s : %v", r.output, err)
+		}
+		r.outFile = fh
+		out = fh
+	}
+	r.out = bufio.NewWriter(out)
+	r.Out = r.out
+	return nil
+}
+
+// FormatFlag registers the -format flag, for selecting the output format of commands printing tabular data (see Format and NewTableWriter). It must be called before Parse.
+func (r *Runner) FormatFlag() {
+	r.flags.Var(&r.format, "format", "Output `format`: "+strings.Join(formatNames, ", ")+" (text is the command's plain tab separated output)")
+}
+
+// Format returns the output format selected by the -format flag (see FormatFlag)
+func (r *Runner) Format() Format {
+	return r.format
+}
+
+// NewTableWriter creates a table writer for the output, using the format selected by the -format flag (see FormatFlag), and the record terminator (see Terminator)
+func (r *Runner) NewTableWriter(header ...string) *TableWriter {
+	tw := NewTableWriter(r.Out, r.format, header...)
+	tw.SetTerminator(r.Terminator())
+	return tw
+}
+
+// LanguageFlag registers the -lang flag, for selecting the language used for language specific conversions, such as casing (see Language). It must be called before Parse.
+func (r *Runner) LanguageFlag() {
+	r.flags.StringVar(&r.lang, "lang", "", "Language `code` (BCP 47) for language specific casing, e.g. tr (dotted/dotless i), nl (initial ij) or el (default: language independent)")
+}
+
+// Language returns the language selected by the -lang flag (language.Und if the flag is not set)
+func (r *Runner) Language() language.Tag {
+	return r.langTag
+}
+
+// Terminator returns the output record terminator: newline, or NUL if the -z flag is set
+func (r *Runner) Terminator() string {
+	if r.nulSeparated {
+		return "\x00"
+	}
+	return "\n"
+}
+
+// Flush flushes buffered output
+func (r *Runner) Flush() error {
+	if r.out == nil {
+		return nil
+	}
+	return r.out.Flush()
+}
+
+// Close flushes buffered output, and closes the output file, if any
+func (r *Runner) Close() error {
+	if err := r.Flush(); err != nil {
+		return err
+	}
+	if r.outFile != nil {
+		return r.outFile.Close()
+	}
+	return nil
+}
+
+// files resolves an input argument into a list of files, or nil if the argument is a literal string
+func (r *Runner) files(in input) ([]string, error) {
+	if in.literal {
+		return nil, nil
+	}
+	if isDir, err := hio.IsDirectory(in.arg); err == nil && isDir {
+		return hio.Find(in.arg, FindOptions)
+	}
+	if r.forceFile || r.ArgsAreFiles || hio.IsFile(in.arg) {
+		return []string{in.arg}, nil
+	}
+	return nil, nil
+}
+
+func (r *Runner) unsupported(flagName string) error {
+	return fmt.Errorf("flag -%s is not supported by %s", flagName, r.Name)
+}
+
+// ForEachInput calls fn for each input: the input files (directories are expanded), the literal string arguments, or stdin if there are no input arguments. If the -F flag is set, the followed file is the only input, and it is read until following stops. Flags -i and -z are not supported, since the input is not split into records.
+func (r *Runner) ForEachInput(fn func(in Input) error) error {
+	defer r.Flush()
+	if r.inPlace {
+		return r.unsupported("i")
+	}
+	if r.nulSeparated {
+		return r.unsupported("z")
+	}
+	if r.colSpecs != nil {
+		return r.unsupported("cols")
+	}
+	return r.forEachInput(fn)
+}
+
+func (r *Runner) forEachInput(fn func(in Input) error) error {
+	if r.follow != "" {
+		return r.followInput(fn)
+	}
+	if len(r.inputs) == 0 {
+		return fn(Input{Name: StdinName, Reader: r.Stdin})
+	}
+	for _, in := range r.inputs {
+		files, err := r.files(in)
+		if err != nil {
+			return err
+		}
+		if files == nil {
+			if err := fn(Input{Name: in.arg, Literal: true, Reader: strings.NewReader(in.arg)}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, f := range files {
+			if err := r.forEachFile(f, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Runner) forEachFile(f string, fn func(in Input) error) error {
+	rd, closer, err := hio.OpenFileReader(f)
+	defer closer.Close()
+	if err != nil {
+		return err
+	}
+	return fn(Input{Name: f, Reader: rd})
+}
+
+// followInput calls fn for the file given by the -F flag, as a single input that is read as data is appended to the file (see io.Follow), until following is stopped (see followCtx)
+func (r *Runner) followInput(fn func(in Input) error) error {
+	ctx := r.followCtx
+	if ctx == nil {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+	}
+	f, err := hio.Follow(ctx, r.follow, r.followOpts)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return fn(Input{Name: r.follow, Reader: f})
+}
+
+func splitNul(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[0:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+const maxRecordSize = 64 * 1024 * 1024
+
+func (r *Runner) newScanner(rd io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	if r.nulSeparated {
+		scanner.Split(splitNul)
+	}
+	return scanner
+}
+
+// RecordError is an error for a specific input record, reported as file:line: error
+type RecordError struct {
+	// Source is the input name (see Input)
+	Source string
+	// Line is the line number (or record number, for NUL separated input), starting at 1
+	Line int
+	// Err is the underlying error
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.Source, e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// scanRecords calls fn for each record in the input, along with its line number (a literal string argument is a single record, at line 1)
+func (r *Runner) scanRecords(in Input, fn func(rec string, lineNo int) error) error {
+	if in.Literal {
+		return fn(in.Name, 1)
+	}
+	scanner := r.newScanner(in.Reader)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if err := fn(scanner.Text(), lineNo); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ForEachRecord calls fn for each input record (line, or NUL separated record if the -z flag is set) in the inputs (see ForEachInput). A literal string argument is treated as a single record. Errors returned by fn are reported with file and line number (see RecordError). The -i flag is not supported (see RunConverter). If the -F flag is set, the output is flushed after each record.
+func (r *Runner) ForEachRecord(fn func(rec string) error) error {
+	defer r.Flush()
+	if r.inPlace {
+		return r.unsupported("i")
+	}
+	if r.colSpecs != nil {
+		return r.unsupported("cols")
+	}
+	return r.forEachInput(func(in Input) error {
+		return r.scanRecords(in, func(rec string, lineNo int) error {
+			if err := fn(rec); err != nil {
+				return &RecordError{Source: in.Name, Line: lineNo, Err: err}
+			}
+			if r.follow != "" {
+				return r.Flush()
+			}
+			return nil
+		})
+	})
+}
+
+// RunConverter converts each input record (see ForEachRecord) using the conversion function, and prints the result. If the -i flag is set, the input files are rewritten with the converted records. The output is closed when done.
+func (r *Runner) RunConverter(convert convertFunc) error {
+	return r.RunConverterE(func(s string) (string, error) {
+		return convert(s), nil
+	})
+}
+
+// RunConverterE works as RunConverter, but for conversion functions that may fail. Conversion errors are handled according to the error policy (see ErrorPolicy); errors are reported as file:line: error. Errors from flushing and closing the output are returned as well.
+func (r *Runner) RunConverterE(convert convertFuncE) (err error) {
+	defer func() {
+		if cerr := r.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return r.runConverter(convert)
+}
+
+func (r *Runner) runConverter(convert convertFuncE) error {
+	if !r.inPlace {
+		return r.forEachInput(func(in Input) error {
+			return r.convertRecords(convert, in, r.out)
+		})
+	}
+	for _, in := range r.inputs {
+		files, err := r.files(in)
+		if err != nil {
+			return err
+		}
+		if files == nil {
+			// not an existing file: let convertInPlace report the error
+			files = []string{in.arg}
+		}
+		for _, f := range files {
+			if err := r.convertInPlace(convert, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convertedRecord is an input record, along with its conversion result
+type convertedRecord struct {
+	rec    string
+	lineNo int
+	// header is true for a header line, which is output as is
+	header bool
+	cols   map[int]bool
+	res    string
+	err    error
+}
+
+func (r *Runner) convertRecord(convert convertFuncE, cr convertedRecord) convertedRecord {
+	switch {
+	case cr.header:
+		cr.res = cr.rec
+	case cr.cols != nil:
+		cr.res, cr.err = ConvertColumns(convert, cr.rec, r.fieldSep, cr.cols)
+	default:
+		cr.res, cr.err = convert(cr.rec)
+	}
+	return cr
+}
+
+// convertRecords converts each record in the input (or the selected columns, if the -cols flag is set), writing the result to w, handling conversion errors according to the error policy. If the -j flag is set, records are converted in parallel (see ProcessOrdered).
+func (r *Runner) convertRecords(convert convertFuncE, in Input, w io.Writer) error {
+	var cols map[int]bool
+	if r.colSpecs != nil && !NeedsHeader(r.colSpecs) {
+		var err error
+		if cols, err = ResolveColumns(r.colSpecs, nil); err != nil {
+			return err
+		}
+	}
+	produce := func(emit func(convertedRecord) error) error {
+		return r.scanRecords(in, func(rec string, lineNo int) error {
+			cr := convertedRecord{rec: rec, lineNo: lineNo, cols: cols}
+			if r.colSpecs != nil && cols == nil {
+				var header []string
+				if !in.Literal {
+					header = strings.Split(rec, r.fieldSep)
+				}
+				// header line: resolve column names, and print the header as is
+				var err error
+				if cols, err = ResolveColumns(r.colSpecs, header); err != nil {
+					return &RecordError{Source: in.Name, Line: lineNo, Err: err}
+				}
+				cr.header = true
+			}
+			return emit(cr)
+		})
+	}
+	write := func(cr convertedRecord) error {
+		res := cr.res
+		if cr.err != nil {
+			recErr := &RecordError{Source: in.Name, Line: cr.lineNo, Err: cr.err}
+			if r.OnError == StopOnError {
+				return recErr
+			}
+			fmt.Fprintf(r.Errors, "%v\n", recErr)
+			if r.OnError == SkipOnError {
+				return nil
+			}
+			res = cr.rec
+		}
+		if _, err := io.WriteString(w, res+r.Terminator()); err != nil {
+			return err
+		}
+		if r.follow != "" {
+			// output each record as soon as it is converted
+			return r.Flush()
+		}
+		return nil
+	}
+	if r.workers == 1 {
+		return produce(func(cr convertedRecord) error {
+			return write(r.convertRecord(convert, cr))
+		})
+	}
+	opts := ParallelOptions{Workers: r.workers, BatchSize: r.batchSize}
+	return ProcessOrdered(opts, produce, func(cr convertedRecord) convertedRecord {
+		return r.convertRecord(convert, cr)
+	}, write)
+}
+
+func (r *Runner) convertInPlace(convert convertFuncE, f string) error {
+	if _, _, isArchivePath := hio.SplitArchivePath(f); isArchivePath || strings.HasSuffix(f, ".gz") {
+		return fmt.Errorf("in-place editing is not supported for compressed or archived files: %s", f)
+	}
+	return hio.ReplaceFile(f, r.backupSuffix, func(w io.Writer) error {
+		return r.forEachFile(f, func(in Input) error {
+			return r.convertRecords(convert, in, w)
+		})
+	})
+}
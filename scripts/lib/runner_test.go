@@ -0,0 +1,419 @@
+package lib
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	hio "github.com/HannaLindgren/go-utils/io"
+)
+
+var fsExpGot = "expected: %#v ; got: %#v"
+
+func newTestRunner(t *testing.T, args []string, paramNames ...string) (*Runner, *bytes.Buffer) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	r := newRunner(flags, "test", "Test runner", paramNames...)
+	r.Stdin = strings.NewReader("stdin 1\nstdin 2\n")
+	if err := r.parse(args); err != nil {
+		t.Fatalf("Got error from parse: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	r.out = bufio.NewWriter(buf)
+	r.Out = r.out
+	return r, buf
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	fName := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(fName, []byte(content), 0640); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	return fName
+}
+
+func TestRunnerConvert(t *testing.T) {
+	fName := writeTestFile(t, "input.txt", "file 1\nfile 2\n")
+	var test = func(args []string, exp string) {
+		r, buf := newTestRunner(t, args)
+		if err := r.RunConverter(strings.ToUpper); err != nil {
+			t.Errorf("Got error from RunConverter: %v", err)
+			return
+		}
+		if got := buf.String(); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test([]string{}, "STDIN 1\nSTDIN 2\n")
+	test([]string{fName}, "FILE 1\nFILE 2\n")
+	test([]string{"hello", fName}, "HELLO\nFILE 1\nFILE 2\n")
+	test([]string{"--", fName, "hello"}, strings.ToUpper(fName)+"\nHELLO\n")
+	test([]string{fName, "--", fName}, "FILE 1\nFILE 2\n"+strings.ToUpper(fName)+"\n")
+	test([]string{"-z", "--", "a", "b"}, "A\x00B\x00")
+}
+
+// failingWriter fails on every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRunnerConvertCloseError(t *testing.T) {
+	r, _ := newTestRunner(t, []string{"--", "hello"})
+	r.out = bufio.NewWriter(failingWriter{})
+	r.Out = r.out
+	if err := r.RunConverter(strings.ToUpper); err == nil {
+		t.Errorf("Expected error from RunConverter when the output cannot be written")
+	}
+}
+
+func TestRunnerForceFile(t *testing.T) {
+	r, _ := newTestRunner(t, []string{"-f", "nonexisting.txt"})
+	if err := r.RunConverter(strings.ToUpper); err == nil {
+		t.Errorf("Expected error for non-existing file with -f")
+	}
+}
+
+func TestRunnerNulSeparated(t *testing.T) {
+	fName := writeTestFile(t, "input.txt", "rec 1\x00rec\n2\x00")
+	r, buf := newTestRunner(t, []string{"-z", fName})
+	if err := r.RunConverter(strings.ToUpper); err != nil {
+		t.Errorf("Got error from RunConverter: %v", err)
+	}
+	if exp, got := "REC 1\x00REC\n2\x00", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestRunnerInPlace(t *testing.T) {
+	fName := writeTestFile(t, "input.txt", "file 1\nfile 2\n")
+	r, buf := newTestRunner(t, []string{"-i", fName})
+	if err := r.RunConverter(strings.ToUpper); err != nil {
+		t.Errorf("Got error from RunConverter: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for in-place editing, got %s", buf.String())
+	}
+	bts, err := os.ReadFile(fName)
+	if err != nil {
+		t.Fatalf("Couldn't read test file: %v", err)
+	}
+	if exp, got := "FILE 1\nFILE 2\n", string(bts); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	r, _ = newTestRunner(t, []string{"-i", "-b", ".bak", fName})
+	if err := r.RunConverter(strings.ToLower); err != nil {
+		t.Errorf("Got error from RunConverter: %v", err)
+	}
+	for f, exp := range map[string]string{fName: "file 1\nfile 2\n", fName + ".bak": "FILE 1\nFILE 2\n"} {
+		bts, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("Couldn't read test file: %v", err)
+		}
+		if got := string(bts); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	if info, err := os.Stat(fName); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("Expected file mode to be kept, got %v", info.Mode())
+	}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if err := newRunner(flags, "test", "").parse([]string{"-i", "--", "hello"}); err == nil {
+		t.Errorf("Expected error for in-place editing of literal strings")
+	}
+	flags = flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if err := newRunner(flags, "test", "").parse([]string{"-b", ".bak", fName}); err == nil {
+		t.Errorf("Expected error for -b without -i")
+	}
+}
+
+func TestRunnerParams(t *testing.T) {
+	r, buf := newTestRunner(t, []string{"1,2", "--", "a\tb"}, "<fields>")
+	if exp, got := []string{"1,2"}, r.Params; len(got) != 1 || got[0] != exp[0] {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	err := r.ForEachRecord(func(rec string) error {
+		_, err := io.WriteString(r.Out, rec+"|")
+		return err
+	})
+	if err != nil {
+		t.Errorf("Got error from ForEachRecord: %v", err)
+	}
+	if exp, got := "a\tb|", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if err := newRunner(flags, "test", "", "<fields>").parse([]string{}); err == nil {
+		t.Errorf("Expected error for missing parameter")
+	}
+}
+
+func TestRunnerForEachInput(t *testing.T) {
+	r, _ := newTestRunner(t, []string{"-z"})
+	if err := r.ForEachInput(func(in Input) error { return nil }); err == nil {
+		t.Errorf("Expected error for -z with ForEachInput")
+	}
+
+	r, buf := newTestRunner(t, []string{})
+	err := r.ForEachInput(func(in Input) error {
+		text, err := in.Text()
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(r.Out, in.Name+":"+text)
+		return err
+	})
+	if err != nil {
+		t.Errorf("Got error from ForEachInput: %v", err)
+	}
+	if exp, got := StdinName+":stdin 1\nstdin 2", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestRunnerErrorPolicy(t *testing.T) {
+	fName := writeTestFile(t, "input.txt", "ok 1\nbad\nok 2\n")
+	convert := func(s string) (string, error) {
+		if s == "bad" {
+			return "", fmt.Errorf("bad input")
+		}
+		return strings.ToUpper(s), nil
+	}
+	var test = func(args []string, exp, expErrs string, expFail bool) {
+		r, buf := newTestRunner(t, append(args, fName))
+		errs := &bytes.Buffer{}
+		r.Errors = errs
+		err := r.RunConverterE(convert)
+		if expFail {
+			var recErr *RecordError
+			if !errors.As(err, &recErr) || recErr.Line != 2 {
+				t.Errorf("Expected record error for line 2, got %v", err)
+			}
+			if exp, got := fName+":2: bad input", fmt.Sprint(err); got != exp {
+				t.Errorf(fsExpGot, exp, got)
+			}
+		} else if err != nil {
+			t.Errorf("Got error from RunConverterE: %v", err)
+		}
+		if got := buf.String(); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+		if got := errs.String(); got != expErrs {
+			t.Errorf(fsExpGot, expErrs, got)
+		}
+	}
+	test([]string{}, "OK 1\n", "", true)
+	test([]string{"-e", "stop"}, "OK 1\n", "", true)
+	test([]string{"-e", "skip"}, "OK 1\nOK 2\n", fName+":2: bad input\n", false)
+	test([]string{"-e", "pass"}, "OK 1\nbad\nOK 2\n", fName+":2: bad input\n", false)
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if err := newRunner(flags, "test", "").parse([]string{"-e", "ignore"}); err == nil {
+		t.Errorf("Expected error for invalid error policy")
+	}
+}
+
+func TestRunnerColumns(t *testing.T) {
+	fName := writeTestFile(t, "input.tsv", "id\tname\tcountry\n1\tåsa\tse\n2\tola\n")
+	var test = func(args []string, exp string) {
+		r, buf := newTestRunner(t, args)
+		if err := r.RunConverter(strings.ToUpper); err != nil {
+			t.Errorf("Got error from RunConverter: %v", err)
+			return
+		}
+		if got := buf.String(); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test([]string{"-cols", "name", fName}, "id\tname\tcountry\n1\tÅSA\tse\n2\tOLA\n")
+	test([]string{"-cols", "2,3", fName}, "id\tNAME\tCOUNTRY\n1\tÅSA\tSE\n2\tOLA\n")
+	test([]string{"-cols", "country,id", fName}, "id\tname\tcountry\n1\tåsa\tSE\n2\tola\n")
+	test([]string{"-cols", "2", "-sep", ";", "--", "a;b;c"}, "a;B;c\n")
+
+	r, _ := newTestRunner(t, []string{"-cols", "missing", fName})
+	if err := r.RunConverter(strings.ToUpper); err == nil {
+		t.Errorf("Expected error for missing column")
+	}
+	r, _ = newTestRunner(t, []string{"-cols", "name", "--", "a\tb"})
+	if err := r.RunConverter(strings.ToUpper); err == nil {
+		t.Errorf("Expected error for column name without header")
+	}
+}
+
+func TestRunnerFlagTerminator(t *testing.T) {
+	var test = func(args []string, expLiteral []bool) {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		r := newRunner(flags, "test", "")
+		r.NoOutputFlag = true
+		// -o takes a value, so that -o -- doesn't terminate the flags
+		flags.String("o", "", "")
+		if err := r.parse(args); err != nil {
+			t.Fatalf("Got error from parse: %v", err)
+		}
+		got := []bool{}
+		for _, in := range r.inputs {
+			got = append(got, in.literal)
+		}
+		if !reflect.DeepEqual(got, expLiteral) {
+			t.Errorf("%v: "+fsExpGot, args, expLiteral, got)
+		}
+	}
+	test([]string{"--", "a"}, []bool{true})
+	test([]string{"-o", "--", "a"}, []bool{false})
+	test([]string{"-o", "--", "--", "a"}, []bool{true})
+	test([]string{"-o=--", "a", "--", "b"}, []bool{false, true})
+	test([]string{"-f", "--", "a"}, []bool{true})
+	test([]string{"a", "--", "b"}, []bool{false, true})
+}
+
+func TestRunnerModeFlags(t *testing.T) {
+	var test = func(mode InputMode, noOutput bool, exp []string) {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		r := newRunner(flags, "test", "")
+		r.Mode = mode
+		r.NoOutputFlag = noOutput
+		if err := r.parse([]string{}); err != nil {
+			t.Fatalf("Got error from parse: %v", err)
+		}
+		got := []string{}
+		flags.VisitAll(func(f *flag.Flag) { got = append(got, f.Name) })
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test(ConvertRecords, false, []string{"F", "b", "batch", "cols", "e", "f", "i", "j", "o", "sep", "z"})
+	test(ReadRecords, false, []string{"F", "f", "o", "z"})
+	test(ReadInputs, false, []string{"F", "f", "o"})
+	test(ReadInputs, true, []string{"F", "f"})
+}
+
+func TestRunnerTableWriterTerminator(t *testing.T) {
+	var newFormatRunner = func(args []string) (*Runner, error) {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		r := newRunner(flags, "test", "")
+		r.Mode = ReadRecords
+		r.Stdin = strings.NewReader("a\x00b\x00")
+		r.FormatFlag()
+		return r, r.parse(args)
+	}
+	r, err := newFormatRunner([]string{"-z", "-format", "tsv"})
+	if err != nil {
+		t.Fatalf("Got error from parse: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	r.out = bufio.NewWriter(buf)
+	r.Out = r.out
+	tw := r.NewTableWriter("rec", "len")
+	err = r.ForEachRecord(func(rec string) error {
+		return tw.Write(rec, len(rec))
+	})
+	if err != nil {
+		t.Errorf("Got error from ForEachRecord: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Errorf("Got error from Close: %v", err)
+	}
+	r.Flush()
+	if exp, got := "rec\tlen\x00a\t1\x00b\t1\x00", buf.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	for _, format := range []string{"json", "jsonl", "md"} {
+		if _, err := newFormatRunner([]string{"-z", "-format", format}); err == nil {
+			t.Errorf("Expected error for -z with -format %s", format)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer that can be read while it is being written to by another goroutine
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
+
+func TestRunnerFollow(t *testing.T) {
+	fName := writeTestFile(t, "server.log", "line 1\n")
+	r, _ := newTestRunner(t, []string{"-F", fName})
+	buf := &syncBuffer{}
+	r.out = bufio.NewWriter(buf)
+	r.Out = r.out
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.followCtx = ctx
+	r.followOpts = hio.FollowOptions{PollInterval: 5 * time.Millisecond, FromStart: true}
+
+	done := make(chan error)
+	go func() {
+		done <- r.RunConverter(strings.ToUpper)
+	}()
+
+	var waitFor = func(exp string) {
+		deadline := time.Now().Add(5 * time.Second)
+		for buf.String() != exp {
+			if time.Now().After(deadline) {
+				t.Fatalf(fsExpGot, exp, buf.String())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	waitFor("LINE 1\n")
+	fh, err := os.OpenFile(fName, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("Couldn't open test file: %v", err)
+	}
+	if _, err := fh.WriteString("line 2\n"); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	fh.Close()
+	waitFor("LINE 1\nLINE 2\n")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Got error from RunConverter: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for RunConverter to stop")
+	}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if err := newRunner(flags, "test", "").parse([]string{"-F", fName, "hello"}); err == nil {
+		t.Errorf("Expected error for -F with input arguments")
+	}
+}
@@ -1,28 +1,7 @@
 package main
 
-import (
-	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"strings"
-
-	"github.com/HannaLindgren/go-utils/scripts/lib"
-	"github.com/HannaLindgren/go-utils/unicode"
-)
+import "github.com/HannaLindgren/go-utils/tools/nfkd"
 
 func main() {
-	cmdname := filepath.Base(os.Args[0])
-	if len(os.Args) > 1 && strings.HasPrefix(os.Args[1], "-h") {
-		fmt.Fprintln(os.Stderr, "Utility script for compatibility decomposition of text data (destructive conversion).")
-		fmt.Fprintln(os.Stderr)
-		fmt.Fprintf(os.Stderr, "Usage: %s <files>\n", cmdname)
-		fmt.Fprintf(os.Stderr, "       or\n")
-		fmt.Fprintf(os.Stderr, "       cat <file> | %s\n", cmdname)
-		os.Exit(1)
-	}
-	err := lib.ConvertAndPrintFromArgsOrStdin(unicode.NFKD, os.Args[1:])
-	if err != nil {
-		log.Fatalf("%v", err)
-	}
+	nfkd.Main()
 }
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/HannaLindgren/go-utils/tools/replace"
+
+func main() {
+	replace.Main()
+}
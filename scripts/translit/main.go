@@ -0,0 +1,7 @@
+package main
+
+import "github.com/HannaLindgren/go-utils/tools/translit"
+
+func main() {
+	translit.Main()
+}
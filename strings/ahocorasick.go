@@ -0,0 +1,229 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/HannaLindgren/go-utils/io"
+)
+
+// PatternMatch is a match found by a Matcher
+type PatternMatch struct {
+	// Pattern is the index of the matching pattern
+	Pattern int
+	// Start is the start byte offset of the match
+	Start int
+	// End is the end byte offset of the match (exclusive)
+	End int
+}
+
+// MatcherOptions are options for NewMatcher
+type MatcherOptions struct {
+	// WholeWords restricts matches to whole words: a match may not start or end next to a letter, mark or digit outside of the match
+	WholeWords bool
+}
+
+type acNode struct {
+	children map[byte]int
+	fail     int
+	// pattern is the index of the pattern ending at this node, or -1
+	pattern int
+	// dict is the closest node in the fail chain with a pattern, or -1
+	dict  int
+	depth int
+}
+
+// Matcher finds multiple patterns in a string in a single pass (Aho-Corasick), in time linear in the length of the input plus the number of matches, irrespective of the number of patterns
+type Matcher struct {
+	patterns []string
+	nodes    []acNode
+	opts     MatcherOptions
+}
+
+// NewMatcher creates a matcher for the patterns. Empty patterns are ignored. If a pattern occurs more than once, matches refer to the first occurrence.
+func NewMatcher(patterns []string, opts MatcherOptions) *Matcher {
+	m := &Matcher{patterns: patterns, opts: opts}
+	m.nodes = []acNode{{children: map[byte]int{}, pattern: -1, dict: -1}}
+	for pi, p := range patterns {
+		if p == "" {
+			continue
+		}
+		n := 0
+		for i := 0; i < len(p); i++ {
+			next, ok := m.nodes[n].children[p[i]]
+			if !ok {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, acNode{children: map[byte]int{}, pattern: -1, dict: -1, depth: m.nodes[n].depth + 1})
+				m.nodes[n].children[p[i]] = next
+			}
+			n = next
+		}
+		if m.nodes[n].pattern < 0 {
+			m.nodes[n].pattern = pi
+		}
+	}
+	// breadth first computation of the fail and dictionary links
+	queue := []int{}
+	for _, child := range m.nodes[0].children {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for b, child := range m.nodes[n].children {
+			f := m.nodes[n].fail
+			for {
+				if next, ok := m.nodes[f].children[b]; ok {
+					m.nodes[child].fail = next
+					break
+				}
+				if f == 0 {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			fail := m.nodes[child].fail
+			if m.nodes[fail].pattern >= 0 {
+				m.nodes[child].dict = fail
+			} else {
+				m.nodes[child].dict = m.nodes[fail].dict
+			}
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// Patterns returns the patterns of the matcher
+func (m *Matcher) Patterns() []string {
+	return m.patterns
+}
+
+// isWordBoundary returns true unless there are word characters (see isWordRune) on both sides of byte offset i
+func isWordBoundary(s string, i int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i:])
+	return i == 0 || i == len(s) || !isWordRune(before) || !isWordRune(after)
+}
+
+// FindAllOverlapping returns all matches, including overlapping ones, in order of end position (and longest first for the same end position)
+func (m *Matcher) FindAllOverlapping(s string) []PatternMatch {
+	res := []PatternMatch{}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		for {
+			if next, ok := m.nodes[n].children[s[i]]; ok {
+				n = next
+				break
+			}
+			if n == 0 {
+				break
+			}
+			n = m.nodes[n].fail
+		}
+		out := n
+		if m.nodes[out].pattern < 0 {
+			out = m.nodes[out].dict
+		}
+		for ; out >= 0; out = m.nodes[out].dict {
+			node := m.nodes[out]
+			match := PatternMatch{Pattern: node.pattern, Start: i + 1 - node.depth, End: i + 1}
+			if m.opts.WholeWords && (!isWordBoundary(s, match.Start) || !isWordBoundary(s, match.End)) {
+				continue
+			}
+			res = append(res, match)
+		}
+	}
+	return res
+}
+
+// FindAll returns the non-overlapping matches, using leftmost-longest semantics: of the matches starting at the leftmost position, the longest one is selected, and the search continues after it
+func (m *Matcher) FindAll(s string) []PatternMatch {
+	// longest holds the index of the longest match for each start position, plus one (zero means no match)
+	longest := make([]int, len(s))
+	all := m.FindAllOverlapping(s)
+	for i, match := range all {
+		if j := longest[match.Start] - 1; j < 0 || match.End > all[j].End {
+			longest[match.Start] = i + 1
+		}
+	}
+	res := []PatternMatch{}
+	for i := 0; i < len(s); {
+		if j := longest[i] - 1; j >= 0 {
+			res = append(res, all[j])
+			i = all[j].End
+			continue
+		}
+		i++
+	}
+	return res
+}
+
+// ReplaceRule is a find/replace rule
+type ReplaceRule struct {
+	// From is the string to find
+	From string
+	// To is the replacement
+	To string
+}
+
+// MultiReplacer replaces multiple strings in a single pass, using a Matcher with leftmost-longest semantics. Unlike applying the rules one by one, the result doesn't depend on the order of the rules, and replaced text is not matched again.
+type MultiReplacer struct {
+	matcher *Matcher
+	rules   []ReplaceRule
+}
+
+// NewMultiReplacer creates a replacer for the rules. If a string to find occurs in more than one rule, the first rule is used.
+func NewMultiReplacer(rules []ReplaceRule, opts MatcherOptions) *MultiReplacer {
+	patterns := make([]string, len(rules))
+	for i, r := range rules {
+		patterns[i] = r.From
+	}
+	return &MultiReplacer{matcher: NewMatcher(patterns, opts), rules: rules}
+}
+
+// Replace returns a copy of the input string with all matches replaced
+func (r *MultiReplacer) Replace(s string) string {
+	matches := r.matcher.FindAll(s)
+	if len(matches) == 0 {
+		return s
+	}
+	var res strings.Builder
+	last := 0
+	for _, m := range matches {
+		res.WriteString(s[last:m.Start])
+		res.WriteString(r.rules[m.Pattern].To)
+		last = m.End
+	}
+	res.WriteString(s[last:])
+	return res.String()
+}
+
+// ReadReplaceRules reads find/replace rules from a tab separated file with two fields per line: the string to find and the replacement (which may be empty). Empty lines and lines starting with # are ignored. A string to find may only occur once.
+func ReadReplaceRules(fName string) ([]ReplaceRule, error) {
+	lines, err := io.ReadFileToLines(fName)
+	if err != nil {
+		return nil, err
+	}
+	res := []ReplaceRule{}
+	seen := map[string]int{}
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		if len(fs) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected 2 tab separated fields, found %d", fName, i+1, len(fs))
+		}
+		if fs[0] == "" {
+			return nil, fmt.Errorf("%s:%d: empty string to find", fName, i+1)
+		}
+		if prev, ok := seen[fs[0]]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicate rule for %s (see line %d)", fName, i+1, fs[0], prev)
+		}
+		seen[fs[0]] = i + 1
+		res = append(res, ReplaceRule{From: fs[0], To: fs[1]})
+	}
+	return res, nil
+}
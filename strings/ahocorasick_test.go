@@ -0,0 +1,94 @@
+package strings
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatcherOverlapping(t *testing.T) {
+	m := NewMatcher([]string{"he", "she", "his", "hers", ""}, MatcherOptions{})
+	got := m.FindAllOverlapping("ushers")
+	exp := []PatternMatch{{1, 1, 4}, {0, 2, 4}, {3, 2, 6}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestMatcherLeftmostLongest(t *testing.T) {
+	var test = func(patterns []string, opts MatcherOptions, in string, exp []string) {
+		m := NewMatcher(patterns, opts)
+		got := []string{}
+		for _, match := range m.FindAll(in) {
+			got = append(got, in[match.Start:match.End])
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test([]string{"he", "she", "his", "hers"}, MatcherOptions{}, "ushers", []string{"she"})
+	test([]string{"a", "ab", "abc", "bcd"}, MatcherOptions{}, "abcd", []string{"abc"})
+	test([]string{"b", "abcd", "bc"}, MatcherOptions{}, "abcx", []string{"bc"})
+	test([]string{"sam", "samba", "ba"}, MatcherOptions{}, "sambal samba", []string{"samba", "samba"})
+	test([]string{"kl.", "kl", "ca", "ca."}, MatcherOptions{}, "kl. 12 ca. 3", []string{"kl.", "ca."})
+	test([]string{"år", "åren"}, MatcherOptions{}, "under åren", []string{"åren"})
+
+	test([]string{"ca", "cat"}, MatcherOptions{WholeWords: true}, "cat catalog ca", []string{"cat", "ca"})
+	test([]string{"t.ex.", "ex"}, MatcherOptions{WholeWords: true}, "t.ex. text ex", []string{"t.ex.", "ex"})
+	test([]string{"år"}, MatcherOptions{WholeWords: true}, "år åren bår", []string{"år"})
+	test([]string{"x"}, MatcherOptions{}, "", []string{})
+}
+
+func TestMultiReplacer(t *testing.T) {
+	rules := []ReplaceRule{
+		{From: "a", To: "b"},
+		{From: "b", To: "a"},
+		{From: "t.ex.", To: "till exempel"},
+		{From: "ex", To: "EX"},
+	}
+	r := NewMultiReplacer(rules, MatcherOptions{WholeWords: true})
+	var test = func(in, exp string) {
+		if got := r.Replace(in); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	// rules are applied simultaneously, not one after the other
+	test("a b ab", "b a ab")
+	test("t.ex. text ex", "till exempel text EX")
+	test("", "")
+
+	// compare with strings.Replacer, which uses leftmost-first semantics for overlapping patterns
+	r = NewMultiReplacer([]ReplaceRule{{From: "ab", To: "X"}, {From: "abc", To: "Y"}}, MatcherOptions{})
+	if got, exp := r.Replace("abcab"), "YX"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if got, exp := strings.NewReplacer("ab", "X", "abc", "Y").Replace("abcab"), "XcX"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestReadReplaceRules(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "rules.tsv")
+	if err := os.WriteFile(fName, []byte("# abbreviations\nt.ex.\ttill exempel\nbl.a.\tbland annat\n\nosv\t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := ReadReplaceRules(fName)
+	if err != nil {
+		t.Fatalf("Got error from ReadReplaceRules: %v", err)
+	}
+	exp := []ReplaceRule{{"t.ex.", "till exempel"}, {"bl.a.", "bland annat"}, {"osv", ""}}
+	if !reflect.DeepEqual(rules, exp) {
+		t.Errorf(fsExpGot, exp, rules)
+	}
+
+	for _, data := range []string{"a\tb\tc\n", "a\tb\na\tc\n", "\tb\n"} {
+		if err := os.WriteFile(fName, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadReplaceRules(fName); err == nil {
+			t.Errorf("Expected error for rule file %q", data)
+		}
+	}
+}
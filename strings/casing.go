@@ -0,0 +1,63 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// ParseLanguage parses a BCP 47 language tag, such as tr, nl or el. The empty string gives language.Und, i.e., language independent casing.
+func ParseLanguage(lang string) (language.Tag, error) {
+	if lang == "" {
+		return language.Und, nil
+	}
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return language.Und, fmt.Errorf("invalid language %s : %v", lang, err)
+	}
+	return tag, nil
+}
+
+// ToUpper converts a string to upper case, using the casing rules of the language, e.g. i => İ for Turkish. Use language.Und for language independent casing.
+func ToUpper(s string, lang language.Tag) string {
+	// a cases.Caser is stateful, so a new one is created for each call, to make the function safe for concurrent use
+	return cases.Upper(lang).String(s)
+}
+
+// ToLower converts a string to lower case, using the casing rules of the language, e.g. I => ı for Turkish. Greek final sigma is handled for all languages.
+func ToLower(s string, lang language.Tag) string {
+	return cases.Lower(lang).String(s)
+}
+
+// isDutch returns true for Dutch, where the digraph ij is upcased as a unit at the start of a word (ijs => IJs)
+func isDutch(lang language.Tag) bool {
+	base, _ := lang.Base()
+	return base.String() == "nl"
+}
+
+// UpcaseInitialLang upcases (title cases) the first character of a string, using the casing rules of the language, and optionally downcases the remainder. For Dutch, an initial ij is upcased as a unit (ijsselmeer => IJsselmeer); for Turkish, an initial i is upcased to İ. For language.Und, strings.ToUpper and strings.ToLower are used, as in UpcaseInitial, so that the result has the same length in runes (ß, ligatures such as ﬁ, and ŉ are kept as is, ǆ becomes Ǆ, Σ becomes σ also at the end of a word, and İ becomes i).
+func UpcaseInitialLang(s string, downcaseRemainder bool, lang language.Tag) string {
+	if s == "" {
+		return s
+	}
+	_, size := utf8.DecodeRuneInString(s)
+	if isDutch(lang) && len(s) >= 2 && strings.EqualFold(s[:2], "ij") {
+		size = 2
+	}
+	head, tail := s[:size], s[size:]
+	if lang == language.Und {
+		head = strings.ToUpper(head)
+		if downcaseRemainder {
+			tail = strings.ToLower(tail)
+		}
+		return head + tail
+	}
+	head = cases.Title(lang, cases.NoLower).String(head)
+	if downcaseRemainder {
+		tail = ToLower(tail, lang)
+	}
+	return head + tail
+}
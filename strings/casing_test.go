@@ -0,0 +1,53 @@
+package strings
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestLanguageCasing(t *testing.T) {
+	var test = func(lang, in, expUpper, expLower string) {
+		tag, err := ParseLanguage(lang)
+		if err != nil {
+			t.Fatalf("Got error from ParseLanguage: %v", err)
+		}
+		if got := ToUpper(in, tag); got != expUpper {
+			t.Errorf(fsExpGot, expUpper, got)
+		}
+		if got := ToLower(in, tag); got != expLower {
+			t.Errorf(fsExpGot, expLower, got)
+		}
+	}
+	test("", "Istanbul", "ISTANBUL", "istanbul")
+	test("tr", "Istanbul ile", "ISTANBUL İLE", "ıstanbul ile")
+	test("el", "ΟΔΟΣ ΟΔΟΣ.", "ΟΔΟΣ ΟΔΟΣ.", "οδος οδος.")
+	test("el", "άλφα", "ΑΛΦΑ", "άλφα")
+
+	if _, err := ParseLanguage("not a language"); err == nil {
+		t.Errorf("Expected error for invalid language")
+	}
+}
+
+func TestUpcaseInitialLang(t *testing.T) {
+	var test = func(lang language.Tag, in, exp string, downcaseRest bool) {
+		if got := UpcaseInitialLang(in, downcaseRest, lang); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test(language.Und, "ijsselmeer", "Ijsselmeer", false)
+	test(language.Dutch, "ijsselmeer", "IJsselmeer", false)
+	test(language.Dutch, "iJSSELMEER", "IJsselmeer", true)
+	test(language.Dutch, "i", "I", false)
+	test(language.Turkish, "istanbul", "İstanbul", false)
+	test(language.Turkish, "iSTANBUL", "İstanbul", true)
+	test(language.Greek, "οΔΟΣ", "Οδος", true)
+	test(language.Und, "", "", true)
+	test(language.Und, "ßtraße", "ßtraße", false)
+	test(language.Und, "ǆem", "Ǆem", false)
+	test(language.Und, "ﬁne", "ﬁne", false)
+	test(language.Und, "aBΣ", "Abσ", true)
+	test(language.Und, "aİ", "Ai", true)
+	test(language.German, "ßtraße", "Sstraße", false)
+	test(language.Croatian, "ǆem", "ǅem", false)
+}
@@ -0,0 +1,503 @@
+package strings
+
+import (
+	"strings"
+)
+
+// doubleMetaphoneMaxLength is the length of the Double Metaphone keys
+const doubleMetaphoneMaxLength = 4
+
+// doubleMetaphone holds the state of a Double Metaphone encoding
+type doubleMetaphone struct {
+	w             []rune
+	primary       strings.Builder
+	secondary     strings.Builder
+	slavoGermanic bool
+	schStart      bool
+	lastIndex     int
+}
+
+// add appends to both keys
+func (dm *doubleMetaphone) add(s string) {
+	dm.add2(s, s)
+}
+
+// add2 appends to the primary and the secondary key
+func (dm *doubleMetaphone) add2(primary, secondary string) {
+	dm.primary.WriteString(primary)
+	dm.secondary.WriteString(secondary)
+}
+
+func (dm *doubleMetaphone) complete() bool {
+	return dm.primary.Len() >= doubleMetaphoneMaxLength && dm.secondary.Len() >= doubleMetaphoneMaxLength
+}
+
+func (dm *doubleMetaphone) at(i int) rune {
+	return at(dm.w, i)
+}
+
+// is returns true if the string at position i is one of the alternatives
+func (dm *doubleMetaphone) is(i int, alts ...string) bool {
+	for _, alt := range alts {
+		n := len(alt)
+		if i >= 0 && i+n <= len(dm.w) && string(dm.w[i:i+n]) == alt {
+			return true
+		}
+	}
+	return false
+}
+
+func (dm *doubleMetaphone) isVowel(i int) bool {
+	return isPhoneticVowel(dm.at(i))
+}
+
+// DoubleMetaphone returns the primary and secondary (alternative) Double Metaphone keys of a word, as defined by Lawrence Philips, e.g. Smith => SM0, XMT and Schmidt => XMT, SMT. The keys are four characters long (at most). Diacritics are removed before encoding, and characters other than letters are ignored, so the input is treated as a single word (the rules for multi-word names such as Van Gogh and San Jacinto are not used).
+func DoubleMetaphone(s string) (string, string) {
+	dm := &doubleMetaphone{w: phoneticLetters(s)}
+	if len(dm.w) == 0 {
+		return "", ""
+	}
+	word := string(dm.w)
+	dm.lastIndex = len(dm.w) - 1
+	dm.slavoGermanic = strings.ContainsAny(word, "WK") || strings.Contains(word, "CZ") || strings.Contains(word, "WITZ")
+	dm.schStart = dm.is(0, "SCH")
+
+	i := 0
+	if dm.is(0, "GN", "KN", "PN", "WR", "PS") {
+		i = 1
+	}
+	// initial X is pronounced Z, as in Xavier
+	if dm.at(0) == 'X' {
+		dm.add("S")
+		i = 1
+	}
+	for i <= dm.lastIndex && !dm.complete() {
+		i = dm.next(i)
+	}
+	p, sec := dm.primary.String(), dm.secondary.String()
+	return p[:min(len(p), doubleMetaphoneMaxLength)], sec[:min(len(sec), doubleMetaphoneMaxLength)]
+}
+
+// next encodes the letter at position i, and returns the position of the next letter to encode
+func (dm *doubleMetaphone) next(i int) int {
+	c := dm.at(i)
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		if i == 0 {
+			dm.add("A")
+		}
+		return i + 1
+	case 'B':
+		dm.add("P")
+		return dm.skip(i, 'B')
+	case 'C':
+		return dm.c(i)
+	case 'D':
+		switch {
+		case dm.is(i, "DG") && dm.is(i+2, "I", "E", "Y"):
+			dm.add("J")
+			return i + 3
+		case dm.is(i, "DG"):
+			dm.add("TK")
+			return i + 2
+		case dm.is(i, "DT", "DD"):
+			dm.add("T")
+			return i + 2
+		}
+		dm.add("T")
+		return i + 1
+	case 'F':
+		dm.add("F")
+		return dm.skip(i, 'F')
+	case 'G':
+		return dm.g(i)
+	case 'H':
+		// only kept if first or between vowels
+		if (i == 0 || dm.isVowel(i-1)) && dm.isVowel(i+1) {
+			dm.add("H")
+			return i + 2
+		}
+		return i + 1
+	case 'J':
+		return dm.j(i)
+	case 'K':
+		dm.add("K")
+		return dm.skip(i, 'K')
+	case 'L':
+		if dm.at(i+1) == 'L' {
+			// Spanish ll, as in cabrillo and gallegos
+			if (i == len(dm.w)-3 && dm.is(i-1, "ILLO", "ILLA", "ALLE")) ||
+				((dm.is(dm.lastIndex-1, "AS", "OS") || dm.is(dm.lastIndex, "A", "O")) && dm.is(i-1, "ALLE")) {
+				dm.add2("L", "")
+				return i + 2
+			}
+			dm.add("L")
+			return i + 2
+		}
+		dm.add("L")
+		return i + 1
+	case 'M':
+		dm.add("M")
+		// dumb, thumb
+		if (dm.is(i-1, "UMB") && (i+1 == dm.lastIndex || dm.is(i+2, "ER"))) || dm.at(i+1) == 'M' {
+			return i + 2
+		}
+		return i + 1
+	case 'N':
+		dm.add("N")
+		return dm.skip(i, 'N')
+	case 'P':
+		if dm.at(i+1) == 'H' {
+			dm.add("F")
+			return i + 2
+		}
+		dm.add("P")
+		if dm.is(i+1, "P", "B") {
+			return i + 2
+		}
+		return i + 1
+	case 'Q':
+		dm.add("K")
+		return dm.skip(i, 'Q')
+	case 'R':
+		// French, as in rogier, but not in hochmeier
+		if i == dm.lastIndex && !dm.slavoGermanic && dm.is(i-2, "IE") && !dm.is(i-4, "ME", "MA") {
+			dm.add2("", "R")
+		} else {
+			dm.add("R")
+		}
+		return dm.skip(i, 'R')
+	case 'S':
+		return dm.s(i)
+	case 'T':
+		return dm.t(i)
+	case 'V':
+		dm.add("F")
+		return dm.skip(i, 'V')
+	case 'W':
+		return dm.wLetter(i)
+	case 'X':
+		// French, as in breaux
+		if !(i == dm.lastIndex && (dm.is(i-3, "IAU", "EAU") || dm.is(i-2, "AU", "OU"))) {
+			dm.add("KS")
+		}
+		if dm.is(i+1, "C", "X") {
+			return i + 2
+		}
+		return i + 1
+	case 'Z':
+		// Chinese pinyin, as in zhao
+		if dm.at(i+1) == 'H' {
+			dm.add("J")
+			return i + 2
+		}
+		if dm.is(i+1, "ZO", "ZI", "ZA") || (dm.slavoGermanic && i > 0 && dm.at(i-1) != 'T') {
+			dm.add2("S", "TS")
+		} else {
+			dm.add("S")
+		}
+		return dm.skip(i, 'Z')
+	}
+	return i + 1
+}
+
+// skip returns the position after the letter at i, skipping a doubled letter
+func (dm *doubleMetaphone) skip(i int, c rune) int {
+	if dm.at(i+1) == c {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (dm *doubleMetaphone) c(i int) int {
+	switch {
+	// various Germanic, as in bacher and macher
+	case i > 1 && !dm.isVowel(i-2) && dm.is(i-1, "ACH") && ((dm.at(i+2) != 'I' && dm.at(i+2) != 'E') || dm.is(i-2, "BACHER", "MACHER")):
+		dm.add("K")
+		return i + 2
+	case i == 0 && dm.is(i, "CAESAR"):
+		dm.add("S")
+		return i + 2
+	// Italian chianti
+	case dm.is(i, "CHIA"):
+		dm.add("K")
+		return i + 2
+	case dm.is(i, "CH"):
+		return dm.ch(i)
+	// Polish czerny
+	case dm.is(i, "CZ") && !dm.is(i-2, "WICZ"):
+		dm.add2("S", "X")
+		return i + 2
+	// Italian focaccia
+	case dm.is(i+1, "CIA"):
+		dm.add("X")
+		return i + 3
+	// double c, but not in McClellan
+	case dm.is(i, "CC") && !(i == 1 && dm.at(0) == 'M'):
+		// bellocchio, but not in bacchus
+		if dm.is(i+2, "I", "E", "H") && !dm.is(i+2, "HU") {
+			// accident, accede, succeed
+			if (i == 1 && dm.at(i-1) == 'A') || dm.is(i-1, "UCCEE", "UCCES") {
+				dm.add("KS")
+			} else {
+				// bacci, bertucci, other Italian
+				dm.add("X")
+			}
+			return i + 3
+		}
+		// Pierce's rule
+		dm.add("K")
+		return i + 2
+	case dm.is(i, "CK", "CG", "CQ"):
+		dm.add("K")
+		return i + 2
+	case dm.is(i, "CI", "CE", "CY"):
+		// Italian vs. English
+		if dm.is(i, "CIO", "CIE", "CIA") {
+			dm.add2("S", "X")
+		} else {
+			dm.add("S")
+		}
+		return i + 2
+	}
+	dm.add("K")
+	// Mac Caffrey, Mac Gregor
+	if dm.is(i+1, "C", "K", "Q") && !dm.is(i+1, "CE", "CI") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (dm *doubleMetaphone) ch(i int) int {
+	switch {
+	// Michael
+	case i > 0 && dm.is(i, "CHAE"):
+		dm.add2("K", "X")
+	// Greek roots, as in chemistry and chorus
+	case i == 0 && (dm.is(i+1, "HARAC", "HARIS") || dm.is(i+1, "HOR", "HYM", "HIA", "HEM")) && !dm.is(0, "CHORE"):
+		dm.add("K")
+	// Germanic, Greek, or otherwise ch for kh sound
+	case dm.schStart ||
+		dm.is(i-2, "ORCHES", "ARCHIT", "ORCHID") ||
+		dm.is(i+2, "T", "S") ||
+		((dm.is(i-1, "A", "O", "U", "E") || i == 0) &&
+			(dm.is(i+2, "L", "R", "N", "M", "B", "H", "F", "V", "W") || i+1 == dm.lastIndex)):
+		dm.add("K")
+	case i > 0:
+		if dm.is(0, "MC") {
+			dm.add("K")
+		} else {
+			dm.add2("X", "K")
+		}
+	default:
+		dm.add("X")
+	}
+	return i + 2
+}
+
+func (dm *doubleMetaphone) g(i int) int {
+	next := dm.at(i + 1)
+	switch {
+	case next == 'H':
+		return dm.gh(i)
+	case next == 'N':
+		switch {
+		case i == 1 && dm.isVowel(0) && !dm.slavoGermanic:
+			dm.add2("KN", "N")
+		// not in cagney
+		case !dm.is(i+2, "EY") && !dm.slavoGermanic:
+			dm.add2("N", "KN")
+		default:
+			dm.add("KN")
+		}
+		return i + 2
+	// tagliaro
+	case dm.is(i+1, "LI") && !dm.slavoGermanic:
+		dm.add2("KL", "L")
+		return i + 2
+	// -ges-, -gep-, -gel-, -gie- at the beginning
+	case i == 0 && (next == 'Y' || dm.is(i+1, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		dm.add2("K", "J")
+		return i + 2
+	// -ger-, -gy-
+	case (dm.is(i+1, "ER") || next == 'Y') && !dm.is(0, "DANGER", "RANGER", "MANGER") && !dm.is(i-1, "E", "I") && !dm.is(i-1, "RGY", "OGY"):
+		dm.add2("K", "J")
+		return i + 2
+	// Italian, as in biaggi
+	case dm.is(i+1, "E", "I", "Y") || dm.is(i-1, "AGGI", "OGGI"):
+		// obvious Germanic
+		if dm.schStart || dm.is(i+1, "ET") {
+			dm.add("K")
+		} else if dm.is(i+1, "IER") {
+			dm.add("J")
+		} else {
+			dm.add2("J", "K")
+		}
+		return i + 2
+	}
+	dm.add("K")
+	return dm.skip(i, 'G')
+}
+
+func (dm *doubleMetaphone) gh(i int) int {
+	switch {
+	case i > 0 && !dm.isVowel(i-1):
+		dm.add("K")
+	// ghislane, ghiradelli
+	case i == 0:
+		if dm.at(i+2) == 'I' {
+			dm.add("J")
+		} else {
+			dm.add("K")
+		}
+	// Parker's rule (with some further refinements), as in hugh, bough and broughton
+	case (i > 1 && dm.is(i-2, "B", "H", "D")) || (i > 2 && dm.is(i-3, "B", "H", "D")) || (i > 3 && dm.is(i-4, "B", "H")):
+	// laugh, McLaughlin, cough, gough, rough, tough
+	case i > 2 && dm.at(i-1) == 'U' && dm.is(i-3, "C", "G", "L", "R", "T"):
+		dm.add("F")
+	case i > 0 && dm.at(i-1) != 'I':
+		dm.add("K")
+	}
+	return i + 2
+}
+
+func (dm *doubleMetaphone) j(i int) int {
+	// obvious Spanish, as in jose
+	if dm.is(i, "JOSE") {
+		if len(dm.w) == 4 {
+			dm.add("H")
+		} else {
+			dm.add2("J", "H")
+		}
+		return i + 1
+	}
+	switch {
+	// Yankelovich/Jankelowicz
+	case i == 0:
+		dm.add2("J", "A")
+	// Spanish pronunciation of b.g. bajador
+	case dm.isVowel(i-1) && !dm.slavoGermanic && (dm.at(i+1) == 'A' || dm.at(i+1) == 'O'):
+		dm.add2("J", "H")
+	case i == dm.lastIndex:
+		dm.add2("J", "")
+	case !dm.is(i+1, "L", "T", "K", "S", "N", "M", "B", "Z") && !dm.is(i-1, "S", "K", "L"):
+		dm.add("J")
+	}
+	return dm.skip(i, 'J')
+}
+
+func (dm *doubleMetaphone) s(i int) int {
+	switch {
+	// special cases island, isle, carlisle, carlysle
+	case dm.is(i-1, "ISL", "YSL"):
+		return i + 1
+	// special case sugar-
+	case i == 0 && dm.is(i, "SUGAR"):
+		dm.add2("X", "S")
+		return i + 1
+	case dm.is(i, "SH"):
+		// Germanic
+		if dm.is(i+1, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			dm.add("S")
+		} else {
+			dm.add("X")
+		}
+		return i + 2
+	// Italian and Armenian
+	case dm.is(i, "SIO", "SIA") || dm.is(i, "SIAN"):
+		if dm.slavoGermanic {
+			dm.add("S")
+		} else {
+			dm.add2("S", "X")
+		}
+		return i + 3
+	// German and anglicisations, as in smith matching schmidt and snider matching schneider; also -sz- in Slavic languages
+	case (i == 0 && dm.is(i+1, "M", "N", "L", "W")) || dm.is(i+1, "Z"):
+		dm.add2("S", "X")
+		if dm.is(i+1, "Z") {
+			return i + 2
+		}
+		return i + 1
+	case dm.is(i, "SC"):
+		// Schlesinger's rule
+		if dm.at(i+2) == 'H' {
+			switch {
+			// Dutch origin, as in school and schooner
+			case dm.is(i+3, "OO", "ER", "EN", "UY", "ED", "EM"):
+				// schermerhorn, schenker
+				if dm.is(i+3, "ER", "EN") {
+					dm.add2("X", "SK")
+				} else {
+					dm.add("SK")
+				}
+			case i == 0 && !dm.isVowel(3) && dm.at(3) != 'W':
+				dm.add2("X", "S")
+			default:
+				dm.add("X")
+			}
+		} else if dm.is(i+2, "I", "E", "Y") {
+			dm.add("S")
+		} else {
+			dm.add("SK")
+		}
+		return i + 3
+	}
+	// French, as in resnais and artois
+	if i == dm.lastIndex && dm.is(i-2, "AI", "OI") {
+		dm.add2("", "S")
+	} else {
+		dm.add("S")
+	}
+	if dm.is(i+1, "S", "Z") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (dm *doubleMetaphone) t(i int) int {
+	switch {
+	case dm.is(i, "TION"), dm.is(i, "TIA", "TCH"):
+		dm.add("X")
+		return i + 3
+	case dm.is(i, "TH") || dm.is(i, "TTH"):
+		// special case thomas, thames or Germanic
+		if dm.is(i+2, "OM", "AM") || dm.schStart {
+			dm.add("T")
+		} else {
+			dm.add2("0", "T")
+		}
+		return i + 2
+	}
+	dm.add("T")
+	if dm.is(i+1, "T", "D") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (dm *doubleMetaphone) wLetter(i int) int {
+	switch {
+	// can also be in the middle of a word
+	case dm.is(i, "WR"):
+		dm.add("R")
+		return i + 2
+	case i == 0 && (dm.isVowel(i+1) || dm.is(i, "WH")):
+		// Wasserman should match Vasserman
+		if dm.isVowel(i + 1) {
+			dm.add2("A", "F")
+		} else {
+			// need Uomo to match Womo
+			dm.add("A")
+		}
+		return i + 1
+	// Arnow should match Arnoff
+	case (i == dm.lastIndex && dm.isVowel(i-1)) || dm.is(i-1, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || dm.is(0, "SCH"):
+		dm.add2("", "F")
+		return i + 1
+	// Polish, as in filipowicz
+	case dm.is(i, "WICZ", "WITZ"):
+		dm.add2("TS", "FX")
+		return i + 4
+	}
+	return i + 1
+}
@@ -0,0 +1,318 @@
+// Package editdist computes edit distances (Levenshtein, Damerau-Levenshtein and weighted variants) and alignments (edit scripts), over strings (runes) or arbitrary token slices, such as phoneme transcriptions.
+package editdist
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/io"
+)
+
+// Op is an edit operation
+type Op int
+
+const (
+	// Match is an unchanged token
+	Match Op = iota
+	// Substitute replaces a token with another
+	Substitute
+	// Insert adds a token
+	Insert
+	// Delete removes a token
+	Delete
+	// Transpose swaps two adjacent tokens
+	Transpose
+)
+
+func (op Op) String() string {
+	switch op {
+	case Match:
+		return "match"
+	case Substitute:
+		return "substitute"
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	case Transpose:
+		return "transpose"
+	}
+	return fmt.Sprintf("Op(%d)", int(op))
+}
+
+// Costs defines the cost of each edit operation. A nil Insert, Delete or Substitute function means unit cost (and zero cost for substituting a token with itself). A nil Transpose function means that transpositions are not allowed (plain Levenshtein).
+type Costs[T comparable] struct {
+	// Insert is the cost of inserting b
+	Insert func(b T) float64
+	// Delete is the cost of deleting a
+	Delete func(a T) float64
+	// Substitute is the cost of replacing a with b (only called for a != b)
+	Substitute func(a, b T) float64
+	// Transpose is the cost of swapping the adjacent tokens a1 a2 into a2 a1
+	Transpose func(a1, a2 T) float64
+}
+
+// LevenshteinCosts are unit costs for insertion, deletion and substitution
+func LevenshteinCosts[T comparable]() Costs[T] {
+	return Costs[T]{}
+}
+
+// DamerauCosts are unit costs for insertion, deletion, substitution and transposition of adjacent tokens
+func DamerauCosts[T comparable]() Costs[T] {
+	return Costs[T]{Transpose: func(a1, a2 T) float64 { return 1 }}
+}
+
+func (c Costs[T]) insert(b T) float64 {
+	if c.Insert == nil {
+		return 1
+	}
+	return c.Insert(b)
+}
+
+func (c Costs[T]) delete(a T) float64 {
+	if c.Delete == nil {
+		return 1
+	}
+	return c.Delete(a)
+}
+
+func (c Costs[T]) substitute(a, b T) float64 {
+	if a == b {
+		return 0
+	}
+	if c.Substitute == nil {
+		return 1
+	}
+	return c.Substitute(a, b)
+}
+
+// Edit is a step in an alignment (edit script)
+type Edit[T comparable] struct {
+	// Op is the edit operation
+	Op Op
+	// A holds the source tokens: one token for match, substitute and delete, two tokens for transpose, and none for insert
+	A []T
+	// B holds the target tokens: one token for match, substitute and insert, two tokens for transpose, and none for delete
+	B []T
+	// APos is the position of the edit in the source sequence
+	APos int
+	// BPos is the position of the edit in the target sequence
+	BPos int
+	// Cost is the cost of the edit
+	Cost float64
+}
+
+func (e Edit[T]) String() string {
+	return fmt.Sprintf("%v %v %v", e.Op, e.A, e.B)
+}
+
+// Alignment is the result of aligning two sequences: the total distance and the edit script transforming the source into the target
+type Alignment[T comparable] struct {
+	// Distance is the total cost of the edits
+	Distance float64
+	// Edits is the edit script, including matches, in sequence order
+	Edits []Edit[T]
+}
+
+// Changes returns the edits that are not matches
+func (a Alignment[T]) Changes() []Edit[T] {
+	res := []Edit[T]{}
+	for _, e := range a.Edits {
+		if e.Op != Match {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// matrix computes the cost matrix and the operation used for each cell
+func matrix[T comparable](a, b []T, costs Costs[T]) ([][]float64, [][]Op) {
+	d := make([][]float64, len(a)+1)
+	ops := make([][]Op, len(a)+1)
+	for i := range d {
+		d[i] = make([]float64, len(b)+1)
+		ops[i] = make([]Op, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		d[i][0] = d[i-1][0] + costs.delete(a[i-1])
+		ops[i][0] = Delete
+	}
+	for j := 1; j <= len(b); j++ {
+		d[0][j] = d[0][j-1] + costs.insert(b[j-1])
+		ops[0][j] = Insert
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			best, op := d[i-1][j-1]+costs.substitute(a[i-1], b[j-1]), Match
+			if a[i-1] != b[j-1] {
+				op = Substitute
+			}
+			if costs.Transpose != nil && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && a[i-1] != a[i-2] {
+				if c := d[i-2][j-2] + costs.Transpose(a[i-2], a[i-1]); c < best {
+					best, op = c, Transpose
+				}
+			}
+			if c := d[i-1][j] + costs.delete(a[i-1]); c < best {
+				best, op = c, Delete
+			}
+			if c := d[i][j-1] + costs.insert(b[j-1]); c < best {
+				best, op = c, Insert
+			}
+			d[i][j], ops[i][j] = best, op
+		}
+	}
+	return d, ops
+}
+
+// Distance returns the edit distance between the token sequences a and b, using the costs. Transpositions (if enabled) follow the optimal string alignment variant of Damerau-Levenshtein, i.e., a transposed pair is not edited further.
+func Distance[T comparable](a, b []T, costs Costs[T]) float64 {
+	d, _ := matrix(a, b, costs)
+	return d[len(a)][len(b)]
+}
+
+// Align returns a minimal cost alignment of the token sequences a and b (see Distance)
+func Align[T comparable](a, b []T, costs Costs[T]) Alignment[T] {
+	d, ops := matrix(a, b, costs)
+	res := Alignment[T]{Distance: d[len(a)][len(b)]}
+	rev := []Edit[T]{}
+	i, j := len(a), len(b)
+	for i > 0 || j > 0 {
+		e := Edit[T]{Op: ops[i][j]}
+		ni, nj := i, j
+		switch e.Op {
+		case Match, Substitute:
+			ni, nj = i-1, j-1
+		case Transpose:
+			ni, nj = i-2, j-2
+		case Delete:
+			ni = i - 1
+		case Insert:
+			nj = j - 1
+		}
+		e.A, e.B = a[ni:i], b[nj:j]
+		e.APos, e.BPos = ni, nj
+		e.Cost = d[i][j] - d[ni][nj]
+		rev = append(rev, e)
+		i, j = ni, nj
+	}
+	for k := len(rev) - 1; k >= 0; k-- {
+		res.Edits = append(res.Edits, rev[k])
+	}
+	return res
+}
+
+// unitDistance computes the unit cost edit distance using three rows of the cost matrix, which is a lot faster than the general weighted version
+func unitDistance[T comparable](a, b []T, transpose bool) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			sub := 1
+			if a[i-1] == b[j-1] {
+				sub = 0
+			}
+			best := min(prev[j-1]+sub, prev[j]+1, cur[j-1]+1)
+			if transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				best = min(best, prev2[j-2]+1)
+			}
+			cur[j] = best
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// Levenshtein returns the Levenshtein distance between two strings, counted in runes
+func Levenshtein(a, b string) int {
+	return unitDistance([]rune(a), []rune(b), false)
+}
+
+// DamerauLevenshtein returns the Damerau-Levenshtein distance (optimal string alignment variant) between two strings, counted in runes
+func DamerauLevenshtein(a, b string) int {
+	return unitDistance([]rune(a), []rune(b), true)
+}
+
+// LevenshteinTokens returns the Levenshtein distance between two token sequences
+func LevenshteinTokens[T comparable](a, b []T) int {
+	return unitDistance(a, b, false)
+}
+
+// DamerauLevenshteinTokens returns the Damerau-Levenshtein distance (optimal string alignment variant) between two token sequences
+func DamerauLevenshteinTokens[T comparable](a, b []T) int {
+	return unitDistance(a, b, true)
+}
+
+// AlignStrings returns a minimal cost alignment of two strings, rune by rune
+func AlignStrings(a, b string, costs Costs[rune]) Alignment[rune] {
+	return Align([]rune(a), []rune(b), costs)
+}
+
+// SubstitutionTable holds custom substitution costs, e.g. a lower cost for substituting similar phonemes or letters, for use as Costs.Substitute
+type SubstitutionTable[T comparable] struct {
+	// Costs holds the custom costs, by source and target token
+	Costs map[T]map[T]float64
+	// Default is the cost for substitutions not in the table
+	Default float64
+	// Symmetric makes a cost for a => b apply to b => a as well (unless b => a is in the table)
+	Symmetric bool
+}
+
+// NewSubstitutionTable creates an empty substitution table, with the default cost for substitutions not in the table
+func NewSubstitutionTable[T comparable](defaultCost float64, symmetric bool) SubstitutionTable[T] {
+	return SubstitutionTable[T]{Costs: map[T]map[T]float64{}, Default: defaultCost, Symmetric: symmetric}
+}
+
+// Set sets the cost for substituting a with b
+func (t SubstitutionTable[T]) Set(a, b T, cost float64) {
+	if t.Costs[a] == nil {
+		t.Costs[a] = map[T]float64{}
+	}
+	t.Costs[a][b] = cost
+}
+
+// Cost returns the cost for substituting a with b
+func (t SubstitutionTable[T]) Cost(a, b T) float64 {
+	if a == b {
+		return 0
+	}
+	if c, ok := t.Costs[a][b]; ok {
+		return c
+	}
+	if t.Symmetric {
+		if c, ok := t.Costs[b][a]; ok {
+			return c
+		}
+	}
+	return t.Default
+}
+
+// ReadSubstitutionTable reads substitution costs from a tab separated file with three fields per line: source token, target token and cost. Empty lines and lines starting with # are ignored.
+func ReadSubstitutionTable(fName string, defaultCost float64, symmetric bool) (SubstitutionTable[string], error) {
+	res := NewSubstitutionTable[string](defaultCost, symmetric)
+	lines, err := io.ReadFileToLines(fName)
+	if err != nil {
+		return res, err
+	}
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		if len(fs) != 3 {
+			return res, fmt.Errorf("%s:%d: expected 3 tab separated fields, found %d", fName, i+1, len(fs))
+		}
+		cost, err := strconv.ParseFloat(fs[2], 64)
+		if err != nil || cost < 0 || math.IsNaN(cost) {
+			return res, fmt.Errorf("%s:%d: invalid cost: %s", fName, i+1, fs[2])
+		}
+		res.Set(fs[0], fs[1], cost)
+	}
+	return res, nil
+}
@@ -0,0 +1,96 @@
+package editdist
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var fsExpGot = "expected: %#v ; got: %#v"
+
+func TestLevenshtein(t *testing.T) {
+	var test = func(a, b string, expLev, expDam int) {
+		if got := Levenshtein(a, b); got != expLev {
+			t.Errorf("Levenshtein(%s, %s) "+fsExpGot, a, b, expLev, got)
+		}
+		if got := DamerauLevenshtein(a, b); got != expDam {
+			t.Errorf("DamerauLevenshtein(%s, %s) "+fsExpGot, a, b, expDam, got)
+		}
+		// the fast unit cost versions should agree with the general version
+		if got := int(Distance([]rune(a), []rune(b), LevenshteinCosts[rune]())); got != expLev {
+			t.Errorf("Distance(%s, %s) "+fsExpGot, a, b, expLev, got)
+		}
+		if got := int(Distance([]rune(a), []rune(b), DamerauCosts[rune]())); got != expDam {
+			t.Errorf("Distance(%s, %s) "+fsExpGot, a, b, expDam, got)
+		}
+		if got := LevenshteinTokens(strings.Split(a, ""), strings.Split(b, "")); got != expLev {
+			t.Errorf("LevenshteinTokens(%s, %s) "+fsExpGot, a, b, expLev, got)
+		}
+	}
+	test("", "", 0, 0)
+	test("", "abc", 3, 3)
+	test("kitten", "sitting", 3, 3)
+	test("ab", "ba", 2, 1)
+	test("smörgås", "smrögås", 2, 1)
+	test("ca", "abc", 3, 3)
+}
+
+func TestWeightedDistance(t *testing.T) {
+	a := strings.Fields("s t r E: m")
+	b := strings.Fields("s t r e: m")
+	table := NewSubstitutionTable[string](1, true)
+	table.Set("e:", "E:", 0.25)
+	costs := Costs[string]{Substitute: table.Cost}
+	if exp, got := 0.25, Distance(a, b, costs); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	costs.Insert = func(b string) float64 { return 2 }
+	if exp, got := 2.25, Distance(a, append(b, "s"), costs); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	al := AlignStrings("gaot", "goat!", DamerauCosts[rune]())
+	if exp, got := 2.0, al.Distance; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	got := []string{}
+	for _, e := range al.Edits {
+		got = append(got, fmt.Sprintf("%v %s>%s %d/%d", e.Op, string(e.A), string(e.B), e.APos, e.BPos))
+	}
+	exp := []string{"match g>g 0/0", "transpose ao>oa 1/1", "match t>t 3/3", "insert >! 4/4"}
+	if strings.Join(got, "|") != strings.Join(exp, "|") {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := 2, len(al.Changes()); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestReadSubstitutionTable(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "costs.tsv")
+	if err := os.WriteFile(fName, []byte("# phoneme costs\ne\tE\t0.5\n\n"), 0600); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	table, err := ReadSubstitutionTable(fName, 1, true)
+	if err != nil {
+		t.Fatalf("Got error from ReadSubstitutionTable: %v", err)
+	}
+	for _, c := range []struct {
+		a, b string
+		exp  float64
+	}{{"e", "E", 0.5}, {"E", "e", 0.5}, {"e", "e", 0}, {"e", "a", 1}} {
+		if got := table.Cost(c.a, c.b); got != c.exp {
+			t.Errorf(fsExpGot, c.exp, got)
+		}
+	}
+	if err := os.WriteFile(fName, []byte("e\tE\n"), 0600); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	if _, err := ReadSubstitutionTable(fName, 1, true); err == nil {
+		t.Errorf("Expected error for invalid line")
+	}
+}
@@ -0,0 +1,167 @@
+package strings
+
+import (
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/io"
+	"github.com/HannaLindgren/go-utils/strings/editdist"
+)
+
+// FuzzyMatch is a search result from a FuzzyIndex
+type FuzzyMatch struct {
+	// Word is the matching entry in the index
+	Word string
+	// Distance is the edit distance between the query and the entry
+	Distance int
+}
+
+// FuzzyIndex is an index for approximate string lookup, implemented as a BK-tree: it finds all entries within a given edit distance from a query, without comparing the query to each entry. The distance function must be a metric (such as Levenshtein distance); the optimal string alignment variant of Damerau-Levenshtein is not a proper metric, and may miss some matches.
+type FuzzyIndex struct {
+	distance func(a, b string) int
+	// runeDistance is used instead of distance for the default Levenshtein distance, to avoid converting the entries to runes for each comparison
+	runeDistance func(a, b []rune) int
+	root         *bkNode
+	size         int
+}
+
+type bkEdge struct {
+	distance int
+	node     *bkNode
+}
+
+type bkNode struct {
+	word     string
+	runes    []rune
+	children []bkEdge
+}
+
+func (n *bkNode) child(distance int) *bkNode {
+	for _, e := range n.children {
+		if e.distance == distance {
+			return e.node
+		}
+	}
+	return nil
+}
+
+// NewFuzzyIndex creates an empty index using the distance function. If distance is nil, Levenshtein distance (counted in runes) is used.
+func NewFuzzyIndex(distance func(a, b string) int) *FuzzyIndex {
+	if distance == nil {
+		return &FuzzyIndex{runeDistance: editdist.LevenshteinTokens[rune]}
+	}
+	return &FuzzyIndex{distance: distance}
+}
+
+func (ix *FuzzyIndex) newNode(word string) *bkNode {
+	n := &bkNode{word: word}
+	if ix.runeDistance != nil {
+		n.runes = []rune(word)
+	}
+	return n
+}
+
+// dist computes the distance between a query (and its runes, for the default distance) and a node
+func (ix *FuzzyIndex) dist(query string, runes []rune, n *bkNode) int {
+	if ix.runeDistance != nil {
+		return ix.runeDistance(runes, n.runes)
+	}
+	return ix.distance(query, n.word)
+}
+
+// Len returns the number of entries in the index
+func (ix *FuzzyIndex) Len() int {
+	return ix.size
+}
+
+// Add adds an entry to the index. Duplicate entries are ignored.
+func (ix *FuzzyIndex) Add(word string) {
+	if ix.root == nil {
+		ix.root = ix.newNode(word)
+		ix.size++
+		return
+	}
+	runes := []rune(word)
+	n := ix.root
+	for {
+		d := ix.dist(word, runes, n)
+		if d == 0 {
+			return
+		}
+		next := n.child(d)
+		if next == nil {
+			n.children = append(n.children, bkEdge{distance: d, node: ix.newNode(word)})
+			ix.size++
+			return
+		}
+		n = next
+	}
+}
+
+// Contains returns true if the word is in the index
+func (ix *FuzzyIndex) Contains(word string) bool {
+	return len(ix.Search(word, 0)) > 0
+}
+
+// Search returns all entries within the maximum edit distance from the query, sorted by distance and then by entry
+func (ix *FuzzyIndex) Search(query string, maxDistance int) []FuzzyMatch {
+	res := []FuzzyMatch{}
+	if ix.root == nil {
+		return res
+	}
+	runes := []rune(query)
+	stack := []*bkNode{ix.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		d := ix.dist(query, runes, n)
+		if d <= maxDistance {
+			res = append(res, FuzzyMatch{Word: n.word, Distance: d})
+		}
+		// by the triangle inequality, matches can only be found below edges in the range d-max..d+max
+		for _, e := range n.children {
+			if e.distance >= d-maxDistance && e.distance <= d+maxDistance {
+				stack = append(stack, e.node)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Distance != res[j].Distance {
+			return res[i].Distance < res[j].Distance
+		}
+		return res[i].Word < res[j].Word
+	})
+	return res
+}
+
+// addLines adds each non-empty line as an entry, trimming surrounding white space
+func (ix *FuzzyIndex) addLines(lines []string) {
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" {
+			ix.Add(l)
+		}
+	}
+}
+
+// BuildFuzzyIndexFromFile creates an index from a file with one entry per line, using the distance function (see NewFuzzyIndex). Empty lines are ignored.
+func BuildFuzzyIndexFromFile(fName string, distance func(a, b string) int) (*FuzzyIndex, error) {
+	lines, err := io.ReadFileToLines(fName)
+	if err != nil {
+		return nil, err
+	}
+	ix := NewFuzzyIndex(distance)
+	ix.addLines(lines)
+	return ix, nil
+}
+
+// BuildFuzzyIndexFromFileFS is like BuildFuzzyIndexFromFile, reading the file from the file system fsys
+func BuildFuzzyIndexFromFileFS(fsys fs.FS, fName string, distance func(a, b string) int) (*FuzzyIndex, error) {
+	lines, err := io.ReadFileToLinesFS(fsys, fName)
+	if err != nil {
+		return nil, err
+	}
+	ix := NewFuzzyIndex(distance)
+	ix.addLines(lines)
+	return ix, nil
+}
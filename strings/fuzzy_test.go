@@ -0,0 +1,97 @@
+package strings
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/HannaLindgren/go-utils/strings/editdist"
+)
+
+func TestFuzzyIndex(t *testing.T) {
+	ix := NewFuzzyIndex(nil)
+	for _, w := range []string{"book", "books", "cake", "boo", "cape", "cart", "boon", "cook", "book"} {
+		ix.Add(w)
+	}
+	if got, exp := ix.Len(), 8; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	var test = func(query string, maxDistance int, exp []FuzzyMatch) {
+		got := ix.Search(query, maxDistance)
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("Search(%s, %d) "+fsExpGot, query, maxDistance, exp, got)
+		}
+	}
+	test("book", 0, []FuzzyMatch{{"book", 0}})
+	test("bool", 0, []FuzzyMatch{})
+	test("bool", 1, []FuzzyMatch{{"boo", 1}, {"book", 1}, {"boon", 1}})
+	test("caqe", 1, []FuzzyMatch{{"cake", 1}, {"cape", 1}})
+	test("cooks", 2, []FuzzyMatch{{"books", 1}, {"cook", 1}, {"book", 2}})
+
+	if !ix.Contains("cart") || ix.Contains("car") {
+		t.Errorf("Contains failed")
+	}
+	if got := NewFuzzyIndex(nil).Search("x", 3); len(got) != 0 {
+		t.Errorf(fsExpGot, []FuzzyMatch{}, got)
+	}
+}
+
+// TestFuzzyIndexBruteForce compares the index search to a linear scan
+func TestFuzzyIndexBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	words := []string{}
+	for i := 0; i < 2000; i++ {
+		n := 2 + rnd.Intn(6)
+		w := make([]rune, n)
+		for j := range w {
+			w[j] = []rune("abcdeåäö")[rnd.Intn(8)]
+		}
+		words = append(words, string(w))
+	}
+	ix := NewFuzzyIndex(nil)
+	for _, w := range words {
+		ix.Add(w)
+	}
+	for _, query := range []string{"abc", "åäö", "deadbe", "a"} {
+		for k := 0; k <= 2; k++ {
+			exp := map[string]int{}
+			for _, w := range words {
+				if d := editdist.Levenshtein(query, w); d <= k {
+					exp[w] = d
+				}
+			}
+			got := map[string]int{}
+			for _, m := range ix.Search(query, k) {
+				got[m.Word] = m.Distance
+			}
+			if !reflect.DeepEqual(got, exp) {
+				t.Errorf("Search(%s, %d) "+fsExpGot, query, k, fmt.Sprint(exp), fmt.Sprint(got))
+			}
+		}
+	}
+}
+
+func TestBuildFuzzyIndexFromFile(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(fName, []byte("hund\nkatt\n\n  hunden \nkatt\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ix, err := BuildFuzzyIndexFromFile(fName, nil)
+	if err != nil {
+		t.Fatalf("Got error from BuildFuzzyIndexFromFile: %v", err)
+	}
+	if got, exp := ix.Len(), 3; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	exp := []FuzzyMatch{{"hund", 1}, {"hunden", 1}}
+	if got := ix.Search("hunde", 1); !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if _, err := BuildFuzzyIndexFromFile(fName+".missing", nil); err == nil {
+		t.Errorf("Expected error for missing file")
+	}
+}
@@ -0,0 +1,308 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// gcbProperty is the Grapheme_Cluster_Break property of a rune (UAX #29)
+type gcbProperty int
+
+const (
+	gcbOther gcbProperty = iota
+	gcbCR
+	gcbLF
+	gcbControl
+	gcbExtend
+	gcbZWJ
+	gcbRegionalIndicator
+	gcbPrepend
+	gcbSpacingMark
+	gcbL
+	gcbV
+	gcbT
+	gcbLV
+	gcbLVT
+)
+
+// prependTable holds the characters with Grapheme_Cluster_Break=Prepend
+var prependTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x0605, Stride: 1},
+		{Lo: 0x06DD, Hi: 0x06DD, Stride: 1},
+		{Lo: 0x070F, Hi: 0x070F, Stride: 1},
+		{Lo: 0x0890, Hi: 0x0891, Stride: 1},
+		{Lo: 0x08E2, Hi: 0x08E2, Stride: 1},
+		{Lo: 0x0D4E, Hi: 0x0D4E, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x110BD, Hi: 0x110BD, Stride: 1},
+		{Lo: 0x110CD, Hi: 0x110CD, Stride: 1},
+		{Lo: 0x111C2, Hi: 0x111C3, Stride: 1},
+		{Lo: 0x1193F, Hi: 0x1193F, Stride: 1},
+		{Lo: 0x11941, Hi: 0x11941, Stride: 1},
+		{Lo: 0x11A3A, Hi: 0x11A3A, Stride: 1},
+		{Lo: 0x11A84, Hi: 0x11A89, Stride: 1},
+		{Lo: 0x11D46, Hi: 0x11D46, Stride: 1},
+	},
+}
+
+// extendExtraTable holds characters with Grapheme_Cluster_Break=Extend that are not non-spacing or enclosing marks: Other_Grapheme_Extend, emoji modifiers and tag characters
+var extendExtraTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x09BE, Hi: 0x09BE, Stride: 1},
+		{Lo: 0x09D7, Hi: 0x09D7, Stride: 1},
+		{Lo: 0x0B3E, Hi: 0x0B3E, Stride: 1},
+		{Lo: 0x0B57, Hi: 0x0B57, Stride: 1},
+		{Lo: 0x0BBE, Hi: 0x0BBE, Stride: 1},
+		{Lo: 0x0BD7, Hi: 0x0BD7, Stride: 1},
+		{Lo: 0x0CC2, Hi: 0x0CC2, Stride: 1},
+		{Lo: 0x0CD5, Hi: 0x0CD6, Stride: 1},
+		{Lo: 0x0D3E, Hi: 0x0D3E, Stride: 1},
+		{Lo: 0x0D57, Hi: 0x0D57, Stride: 1},
+		{Lo: 0x0DCF, Hi: 0x0DCF, Stride: 1},
+		{Lo: 0x0DDF, Hi: 0x0DDF, Stride: 1},
+		{Lo: 0x200C, Hi: 0x200C, Stride: 1},
+		{Lo: 0x302E, Hi: 0x302F, Stride: 1},
+		{Lo: 0xFF9E, Hi: 0xFF9F, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1D165, Hi: 0x1D165, Stride: 1},
+		{Lo: 0x1D16E, Hi: 0x1D172, Stride: 1},
+		{Lo: 0x1F3FB, Hi: 0x1F3FF, Stride: 1},
+		{Lo: 0xE0020, Hi: 0xE007F, Stride: 1},
+	},
+}
+
+// extPictTable approximates the Extended_Pictographic property (emoji and pictographic symbols)
+var extPictTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+		{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21A9, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x2388, Hi: 0x2388, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x2605, Stride: 1},
+		{Lo: 0x2607, Hi: 0x2612, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2685, Stride: 1},
+		{Lo: 0x2690, Hi: 0x2705, Stride: 1},
+		{Lo: 0x2708, Hi: 0x2712, Stride: 1},
+		{Lo: 0x2714, Hi: 0x2714, Stride: 1},
+		{Lo: 0x2716, Hi: 0x2716, Stride: 1},
+		{Lo: 0x271D, Hi: 0x271D, Stride: 1},
+		{Lo: 0x2721, Hi: 0x2721, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x2733, Hi: 0x2734, Stride: 1},
+		{Lo: 0x2744, Hi: 0x2744, Stride: 1},
+		{Lo: 0x2747, Hi: 0x2747, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2763, Hi: 0x2767, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27A1, Hi: 0x27A1, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B07, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F0FF, Stride: 1},
+		{Lo: 0x1F10D, Hi: 0x1F10F, Stride: 1},
+		{Lo: 0x1F12F, Hi: 0x1F12F, Stride: 1},
+		{Lo: 0x1F16C, Hi: 0x1F171, Stride: 1},
+		{Lo: 0x1F17E, Hi: 0x1F17F, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F1AD, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F201, Hi: 0x1F20F, Stride: 1},
+		{Lo: 0x1F21A, Hi: 0x1F21A, Stride: 1},
+		{Lo: 0x1F22F, Hi: 0x1F22F, Stride: 1},
+		{Lo: 0x1F232, Hi: 0x1F23A, Stride: 1},
+		{Lo: 0x1F23C, Hi: 0x1F23F, Stride: 1},
+		{Lo: 0x1F249, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1F53D, Stride: 1},
+		{Lo: 0x1F546, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1},
+		{Lo: 0x1F774, Hi: 0x1F77F, Stride: 1},
+		{Lo: 0x1F7D5, Hi: 0x1F7FF, Stride: 1},
+		{Lo: 0x1F80C, Hi: 0x1F80F, Stride: 1},
+		{Lo: 0x1F848, Hi: 0x1F84F, Stride: 1},
+		{Lo: 0x1F85A, Hi: 0x1F85F, Stride: 1},
+		{Lo: 0x1F888, Hi: 0x1F88F, Stride: 1},
+		{Lo: 0x1F8AE, Hi: 0x1F8FF, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F93A, Stride: 1},
+		{Lo: 0x1F93C, Hi: 0x1F945, Stride: 1},
+		{Lo: 0x1F947, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+	},
+}
+
+// gcb returns the Grapheme_Cluster_Break property of a rune. The property is derived from the general categories in the unicode package, with additional tables for the properties that can't be derived; Hangul syllable types are computed.
+func gcb(r rune) gcbProperty {
+	switch {
+	case r == '\r':
+		return gcbCR
+	case r == '\n':
+		return gcbLF
+	case r == 0x200D:
+		return gcbZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gcbRegionalIndicator
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gcbL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gcbV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gcbT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gcbLV
+		}
+		return gcbLVT
+	case unicode.In(r, unicode.Mn, unicode.Me, extendExtraTable):
+		return gcbExtend
+	case unicode.Is(prependTable, r):
+		return gcbPrepend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gcbControl
+	case unicode.Is(unicode.Mc, r), r == 0x0E33, r == 0x0EB3:
+		return gcbSpacingMark
+	}
+	return gcbOther
+}
+
+// isGraphemeBoundary returns true if there is a grapheme cluster boundary between runes with the properties prev and next (UAX #29 rules GB3-GB13). The state holds the number of preceding regional indicators (for GB12/13), and whether the preceding runes form an emoji sequence ending in ZWJ (for GB11).
+func isGraphemeBoundary(prev, next gcbProperty, nRegional int, emojiZWJ bool, nextIsPict bool) bool {
+	switch {
+	case prev == gcbCR && next == gcbLF: // GB3
+		return false
+	case prev == gcbCR || prev == gcbLF || prev == gcbControl: // GB4
+		return true
+	case next == gcbCR || next == gcbLF || next == gcbControl: // GB5
+		return true
+	case prev == gcbL && (next == gcbL || next == gcbV || next == gcbLV || next == gcbLVT): // GB6
+		return false
+	case (prev == gcbLV || prev == gcbV) && (next == gcbV || next == gcbT): // GB7
+		return false
+	case (prev == gcbLVT || prev == gcbT) && next == gcbT: // GB8
+		return false
+	case next == gcbExtend || next == gcbZWJ: // GB9
+		return false
+	case next == gcbSpacingMark: // GB9a
+		return false
+	case prev == gcbPrepend: // GB9b
+		return false
+	case emojiZWJ && nextIsPict: // GB11
+		return false
+	case prev == gcbRegionalIndicator && next == gcbRegionalIndicator: // GB12, GB13
+		return nRegional%2 == 0
+	}
+	return true // GB999
+}
+
+// FirstGrapheme returns the first grapheme cluster of the input string (as defined by UAX #29, Unicode Text Segmentation), and the remainder of the string
+func FirstGrapheme(s string) (string, string) {
+	if s == "" {
+		return "", ""
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	prev := gcb(r)
+	nRegional := 0
+	if prev == gcbRegionalIndicator {
+		nRegional = 1
+	}
+	// inPict is true if the cluster so far is an Extended_Pictographic rune followed by Extend runes
+	inPict := unicode.Is(extPictTable, r)
+	emojiZWJ := false
+	pos := size
+	for pos < len(s) {
+		r, size = utf8.DecodeRuneInString(s[pos:])
+		next := gcb(r)
+		nextIsPict := unicode.Is(extPictTable, r)
+		if isGraphemeBoundary(prev, next, nRegional, emojiZWJ, nextIsPict) {
+			break
+		}
+		emojiZWJ = inPict && next == gcbZWJ
+		inPict = nextIsPict || (inPict && next == gcbExtend)
+		if next == gcbRegionalIndicator {
+			nRegional++
+		} else {
+			nRegional = 0
+		}
+		prev = next
+		pos += size
+	}
+	return s[:pos], s[pos:]
+}
+
+// Graphemes splits the input string into grapheme clusters (user-perceived characters, as defined by UAX #29, Unicode Text Segmentation), such as a base letter with combining marks, a flag emoji, an emoji ZWJ sequence, or a Hangul syllable made up of conjoining jamo. The Grapheme_Cluster_Break properties are derived from the unicode package tables, and the pictographic/emoji property is approximated by the emoji blocks.
+func Graphemes(s string) []string {
+	res := []string{}
+	for s != "" {
+		var g string
+		g, s = FirstGrapheme(s)
+		res = append(res, g)
+	}
+	return res
+}
+
+// GraphemeCount returns the number of grapheme clusters (user-perceived characters) in the input string (see Graphemes)
+func GraphemeCount(s string) int {
+	n := 0
+	for s != "" {
+		_, s = FirstGrapheme(s)
+		n++
+	}
+	return n
+}
+
+// TruncateGraphemes returns the first n grapheme clusters of the input string (see Graphemes)
+func TruncateGraphemes(s string, n int) string {
+	pos := 0
+	for i := 0; i < n && pos < len(s); i++ {
+		g, _ := FirstGrapheme(s[pos:])
+		pos += len(g)
+	}
+	return s[:pos]
+}
+
+// SubstringGraphemes returns the grapheme clusters from start (inclusive) to end (exclusive) of the input string, counted in grapheme clusters (see Graphemes). Out of range indices are clamped to the string bounds.
+func SubstringGraphemes(s string, start, end int) string {
+	gs := Graphemes(s)
+	start = max(0, min(start, len(gs)))
+	end = max(start, min(end, len(gs)))
+	return strings.Join(gs[start:end], "")
+}
+
+// ReverseRunes reverses a string rune by rune. Combining marks, emoji sequences etc are broken up (see Reverse).
+func ReverseRunes(str string) string {
+	bts := []rune(str)
+	for i, j := 0, len(bts)-1; i < j; i, j = i+1, j-1 {
+		bts[i], bts[j] = bts[j], bts[i]
+	}
+	return string(bts)
+}
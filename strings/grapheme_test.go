@@ -0,0 +1,56 @@
+package strings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	var test = func(in string, exp []string) {
+		got := Graphemes(in)
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+		if GraphemeCount(in) != len(exp) {
+			t.Errorf(fsExpGot, len(exp), GraphemeCount(in))
+		}
+	}
+	test("", []string{})
+	test("abc", []string{"a", "b", "c"})
+	test("e\u0301a", []string{"e\u0301", "a"})
+	test("a\r\nb\n\n", []string{"a", "\r\n", "b", "\n", "\n"})
+	// regional indicators pair up into flags
+	test("🇸🇪🇳🇴🇫", []string{"🇸🇪", "🇳🇴", "🇫"})
+	// emoji ZWJ sequence and emoji modifier
+	test("👨\u200D👩\u200D👧x👍🏽", []string{"👨\u200D👩\u200D👧", "x", "👍🏽"})
+	// ZWJ without preceding pictograph doesn't join
+	test("a\u200D👩", []string{"a\u200D", "👩"})
+	// Hangul conjoining jamo (L V T) and precomposed LV + T
+	test("\u1100\u1161\u11A8\uAC00\u11A8\u1100", []string{"\u1100\u1161\u11A8", "\uAC00\u11A8", "\u1100"})
+	// spacing mark (Devanagari)
+	test("\u0915\u093F", []string{"\u0915\u093F"})
+	// prepend
+	test("\u06001", []string{"\u06001"})
+}
+
+func TestGraphemeHelpers(t *testing.T) {
+	s := "e\u0301🇸🇪ab"
+	if exp, got := "e\u0301🇸🇪", TruncateGraphemes(s, 2); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := s, TruncateGraphemes(s, 10); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := "🇸🇪a", SubstringGraphemes(s, 1, 3); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := "", SubstringGraphemes(s, 3, 1); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := "ba🇸🇪e\u0301", Reverse(s); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if exp, got := "\u0301eab", ReverseRunes("bae\u0301"); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
@@ -0,0 +1,337 @@
+package strings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	uc "github.com/HannaLindgren/go-utils/unicode"
+)
+
+// phoneticLetters folds the input (removing diacritics, see unicode.Fold), and returns the upper case letters A-Z only
+func phoneticLetters(s string) []rune {
+	res := []rune{}
+	for _, r := range strings.ToUpper(uc.Fold(s, false)) {
+		if r >= 'A' && r <= 'Z' {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// at returns the rune at position i, or 0 if i is out of range
+func at(rs []rune, i int) rune {
+	if i < 0 || i >= len(rs) {
+		return 0
+	}
+	return rs[i]
+}
+
+func isPhoneticVowel(r rune) bool {
+	return strings.ContainsRune("AEIOUY", r)
+}
+
+var soundexCodes = map[rune]byte{
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+// Soundex returns the American Soundex code of a word: the initial letter followed by three digits, e.g. Robert => R163. Diacritics are removed before encoding, and characters other than letters are ignored. The empty string is returned for input without letters.
+func Soundex(s string) string {
+	rs := phoneticLetters(s)
+	if len(rs) == 0 {
+		return ""
+	}
+	res := []byte{byte(rs[0])}
+	last := soundexCodes[rs[0]]
+	for _, r := range rs[1:] {
+		code, ok := soundexCodes[r]
+		switch {
+		case ok && code != last:
+			res = append(res, code)
+			last = code
+		case !ok && r != 'H' && r != 'W':
+			// vowels separate letters with the same code, but H and W don't
+			last = 0
+		}
+		if len(res) == 4 {
+			break
+		}
+	}
+	for len(res) < 4 {
+		res = append(res, '0')
+	}
+	return string(res)
+}
+
+// Metaphone returns the (original) Metaphone key of a word, e.g. Knight => NT and Thumb => 0M. Diacritics are removed before encoding, and characters other than letters are ignored. The key is not truncated.
+func Metaphone(s string) string {
+	w := phoneticLetters(s)
+	if len(w) == 0 {
+		return ""
+	}
+	switch string(w[:min(2, len(w))]) {
+	case "KN", "GN", "PN", "AE", "WR":
+		w = w[1:]
+	case "WH":
+		w = append([]rune{'W'}, w[2:]...)
+	}
+	if w[0] == 'X' {
+		w[0] = 'S'
+	}
+	var res strings.Builder
+	for i, c := range w {
+		prev, next, next2 := at(w, i-1), at(w, i+1), at(w, i+2)
+		if c == prev && c != 'C' {
+			continue
+		}
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				res.WriteRune(c)
+			}
+		case 'B':
+			if !(prev == 'M' && i == len(w)-1) {
+				res.WriteRune('B')
+			}
+		case 'C':
+			switch {
+			case next == 'I' && next2 == 'A':
+				res.WriteRune('X')
+			case next == 'H':
+				if prev == 'S' {
+					res.WriteRune('K')
+				} else {
+					res.WriteRune('X')
+				}
+			case next == 'I' || next == 'E' || next == 'Y':
+				if prev != 'S' {
+					res.WriteRune('S')
+				}
+			default:
+				res.WriteRune('K')
+			}
+		case 'D':
+			if next == 'G' && strings.ContainsRune("EIY", next2) {
+				res.WriteRune('J')
+			} else {
+				res.WriteRune('T')
+			}
+		case 'G':
+			switch {
+			case next == 'H' && i+2 < len(w) && !isPhoneticVowel(next2):
+				// silent, as in night
+			case next == 'N' && (i+2 == len(w) || (next2 == 'E' && at(w, i+3) == 'D' && i+4 == len(w))):
+				// silent, as in sign and signed
+			case prev == 'D' && strings.ContainsRune("EIY", next):
+				// already encoded as part of dge, dgi and dgy
+			case strings.ContainsRune("EIY", next) && prev != 'G':
+				res.WriteRune('J')
+			default:
+				res.WriteRune('K')
+			}
+		case 'H':
+			if strings.ContainsRune("CGPST", prev) || (isPhoneticVowel(prev) && !isPhoneticVowel(next)) {
+				continue
+			}
+			res.WriteRune('H')
+		case 'K':
+			if prev != 'C' {
+				res.WriteRune('K')
+			}
+		case 'P':
+			if next == 'H' {
+				res.WriteRune('F')
+			} else {
+				res.WriteRune('P')
+			}
+		case 'Q':
+			res.WriteRune('K')
+		case 'S':
+			if next == 'H' || (next == 'I' && (next2 == 'O' || next2 == 'A')) {
+				res.WriteRune('X')
+			} else {
+				res.WriteRune('S')
+			}
+		case 'T':
+			switch {
+			case next == 'I' && (next2 == 'A' || next2 == 'O'):
+				res.WriteRune('X')
+			case next == 'H':
+				res.WriteRune('0')
+			case next == 'C' && next2 == 'H':
+				// silent, as in watch
+			default:
+				res.WriteRune('T')
+			}
+		case 'V':
+			res.WriteRune('F')
+		case 'W', 'Y':
+			if isPhoneticVowel(next) {
+				res.WriteRune(c)
+			}
+		case 'X':
+			res.WriteString("KS")
+		case 'Z':
+			res.WriteRune('S')
+		default:
+			res.WriteRune(c)
+		}
+	}
+	return res.String()
+}
+
+// nordicVowels are the vowels of the Scandinavian languages; nordicFrontVowels are the front vowels that change the pronunciation of a preceding k, g or sk
+const (
+	nordicVowels      = "aeiouyåäöæøéèáàüë"
+	nordicFrontVowels = "eiyäöæøéèü"
+)
+
+// nordicRules are rewrite rules for NordicKey, applied in order at each position; the first matching rule is used
+var nordicRules = []struct {
+	from string
+	to   string
+	// initial restricts the rule to the start of a word
+	initial bool
+	// beforeFront restricts the rule to positions before a front vowel
+	beforeFront bool
+	// final restricts the rule to the end of a word
+	final bool
+}{
+	// sj sound
+	{from: "sch", to: "X"},
+	{from: "skj", to: "X"},
+	{from: "stj", to: "X"},
+	{from: "sj", to: "X"},
+	{from: "sh", to: "X"},
+	{from: "sk", to: "X", beforeFront: true},
+	{from: "ch", to: "K", initial: true},
+	{from: "ch", to: "X"},
+	// tj sound
+	{from: "tj", to: "C"},
+	{from: "kj", to: "C"},
+	{from: "k", to: "C", beforeFront: true},
+	// j sound
+	{from: "dj", to: "J", initial: true},
+	{from: "gj", to: "J", initial: true},
+	{from: "hj", to: "J", initial: true},
+	{from: "lj", to: "J", initial: true},
+	{from: "g", to: "J", initial: true, beforeFront: true},
+	{from: "j", to: "J"},
+	// spelling variants
+	{from: "ck", to: "K"},
+	{from: "c", to: "S", beforeFront: true},
+	{from: "c", to: "K"},
+	{from: "qu", to: "KV"},
+	{from: "q", to: "K"},
+	{from: "x", to: "KS"},
+	{from: "z", to: "S"},
+	{from: "ph", to: "F"},
+	{from: "th", to: "T"},
+	{from: "dt", to: "T"},
+	{from: "hv", to: "V"},
+	{from: "fv", to: "V"},
+	{from: "w", to: "V"},
+	{from: "f", to: "V", final: true},
+	{from: "ng", to: "N"},
+}
+
+// NordicKey returns a phonetic key for Scandinavian (Swedish, Norwegian and Danish) words and names, so that spelling variants such as Åsa/Asa, Carl/Karl, Gustaf/Gustav, Olof/Ulf, Sjöberg/Schöberg and Kjell/Tjell get the same key. The key is the consonant skeleton of the word, in upper case, with repeated sounds collapsed; vowels are removed, except at the start of the word, where any vowel is written as A.
+func NordicKey(s string) string {
+	w := []rune(strings.ToLower(uc.NFC(s)))
+	isFront := func(i int) bool { return i < len(w) && strings.ContainsRune(nordicFrontVowels, w[i]) }
+	codes := []string{}
+	for i := 0; i < len(w); {
+		if !unicode.IsLetter(w[i]) {
+			i++
+			continue
+		}
+		matched := false
+		for _, rule := range nordicRules {
+			from := []rune(rule.from)
+			if i+len(from) > len(w) || string(w[i:i+len(from)]) != rule.from ||
+				(rule.initial && i > 0) || (rule.beforeFront && !isFront(i+len(from))) || (rule.final && i+len(from) != len(w)) {
+				continue
+			}
+			codes = append(codes, rule.to)
+			i += len(from)
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+		r := w[i]
+		switch {
+		case strings.ContainsRune(nordicVowels, r):
+			if i == 0 {
+				codes = append(codes, "A")
+			} else {
+				// vowels are not written, but separate repeated consonants (Lola => LL, but Anna => AN)
+				codes = append(codes, "")
+			}
+		case r == 'h':
+			if i == 0 {
+				codes = append(codes, "H")
+			}
+		default:
+			codes = append(codes, strings.ToUpper(uc.Fold(string(r), false)))
+		}
+		i++
+	}
+	var res strings.Builder
+	last := ""
+	for _, c := range codes {
+		if c != last {
+			res.WriteString(c)
+		}
+		last = c
+	}
+	return res.String()
+}
+
+// DoubleMetaphonePrimary returns the primary Double Metaphone key (see DoubleMetaphone)
+func DoubleMetaphonePrimary(s string) string {
+	p, _ := DoubleMetaphone(s)
+	return p
+}
+
+// PhoneticKeys holds the phonetic key functions by name, for matching words by sound rather than spelling (see PhoneticKey)
+var PhoneticKeys = map[string]func(string) string{
+	"soundex":    Soundex,
+	"metaphone":  Metaphone,
+	"dmetaphone": DoubleMetaphonePrimary,
+	"nordic":     NordicKey,
+}
+
+// PhoneticKeyNames returns the names of the phonetic key functions, sorted
+func PhoneticKeyNames() []string {
+	res := []string{}
+	for name := range PhoneticKeys {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// PhoneticKey returns the named phonetic key function (see PhoneticKeys), for use on phrases: the function splits the input into words, and joins the keys of the words with a space
+func PhoneticKey(name string) (func(string) string, error) {
+	f, ok := PhoneticKeys[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown phonetic key %s, expected one of: %s", name, strings.Join(PhoneticKeyNames(), ", "))
+	}
+	return func(s string) string {
+		keys := []string{}
+		for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsMark(r) }) {
+			if k := f(w); k != "" {
+				keys = append(keys, k)
+			}
+		}
+		return strings.Join(keys, " ")
+	}, nil
+}
@@ -0,0 +1,122 @@
+package strings
+
+import (
+	"testing"
+)
+
+func TestSoundex(t *testing.T) {
+	var test = func(in, exp string) {
+		if got := Soundex(in); got != exp {
+			t.Errorf("Soundex(%s) "+fsExpGot, in, exp, got)
+		}
+	}
+	test("Robert", "R163")
+	test("Rupert", "R163")
+	test("Rubin", "R150")
+	test("Ashcraft", "A261")
+	test("Ashcroft", "A261")
+	test("Tymczak", "T522")
+	test("Pfister", "P236")
+	test("Honeyman", "H555")
+	test("Lee", "L000")
+	test("Åsa", "A200")
+	test("123", "")
+}
+
+func TestMetaphone(t *testing.T) {
+	var test = func(in, exp string) {
+		if got := Metaphone(in); got != exp {
+			t.Errorf("Metaphone(%s) "+fsExpGot, in, exp, got)
+		}
+	}
+	test("knight", "NT")
+	test("Thumb", "0M")
+	test("phone", "FN")
+	test("Xavier", "SFR")
+	test("school", "SKL")
+	test("science", "SNS")
+	test("witch", "WX")
+	test("Wright", "RT")
+	test("judge", "JJ")
+	test("edge", "EJ")
+	test("Smith", "SM0")
+}
+
+func TestDoubleMetaphone(t *testing.T) {
+	var test = func(in, expPrimary, expSecondary string) {
+		p, s := DoubleMetaphone(in)
+		if p != expPrimary || s != expSecondary {
+			t.Errorf("DoubleMetaphone(%s) "+fsExpGot, in, []string{expPrimary, expSecondary}, []string{p, s})
+		}
+	}
+	test("Smith", "SM0", "XMT")
+	test("Schmidt", "XMT", "SMT")
+	test("Thompson", "TMPS", "TMPS")
+	test("Knight", "NT", "NT")
+	test("Caesar", "SSR", "SSR")
+	test("Michael", "MKL", "MXL")
+	test("Chemistry", "KMST", "KMST")
+	test("Jose", "HS", "HS")
+	test("Xavier", "SF", "SFR")
+	test("Wasserman", "ASRM", "FSRM")
+	test("Filipowicz", "FLPT", "FLPF")
+	test("laugh", "LF", "LF")
+	test("Bacchus", "PKS", "PKS")
+	test("Gallegos", "KLKS", "KKS")
+	test("Müller", "MLR", "MLR")
+	test("", "", "")
+}
+
+func TestNordicKey(t *testing.T) {
+	var same = func(words ...string) {
+		exp := NordicKey(words[0])
+		for _, w := range words[1:] {
+			if got := NordicKey(w); got != exp {
+				t.Errorf("NordicKey(%s) "+fsExpGot, w, exp, got)
+			}
+		}
+	}
+	same("Åsa", "Asa", "ÅSA")
+	same("Carl", "Karl")
+	same("Gustaf", "Gustav")
+	same("Olof", "Ulf")
+	same("Sjöberg", "Schöberg", "Skjöberg")
+	same("Kjell", "Tjell")
+	same("Christer", "Krister")
+	same("Anna", "Ana")
+	same("Mattsson", "Matsson")
+	same("Jöran", "Göran", "Hjöran")
+	same("Wilhelm", "Vilhelm")
+
+	var test = func(in, exp string) {
+		if got := NordicKey(in); got != exp {
+			t.Errorf("NordicKey(%s) "+fsExpGot, in, exp, got)
+		}
+	}
+	test("Åsa", "AS")
+	test("Lola", "LL")
+	test("Kerstin", "CRSTN")
+	test("Skåne", "SKN")
+	test("Hans", "HNS")
+	test("Edvard", "ADVRD")
+}
+
+func TestPhoneticKey(t *testing.T) {
+	f, err := PhoneticKey("soundex")
+	if err != nil {
+		t.Fatalf("Got error from PhoneticKey: %v", err)
+	}
+	if got, exp := f("Robert Rubin, jr."), "R163 R150 J600"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	f, err = PhoneticKey("nordic")
+	if err != nil {
+		t.Fatalf("Got error from PhoneticKey: %v", err)
+	}
+	if got, exp := f("Carl-Gustaf"), f("Karl Gustav"); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if _, err := PhoneticKey("nosuchkey"); err == nil {
+		t.Errorf("Expected error for unknown key")
+	}
+}
@@ -0,0 +1,225 @@
+package strings
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/HannaLindgren/go-utils/io"
+)
+
+// Abbreviations holds the built-in abbreviation lists by language code, used by NewSegmenter. Abbreviations are in lower case, without the final period (e.g. "e.g" and "dr").
+var Abbreviations = map[string][]string{
+	"da": {
+		"adr", "ang", "bl.a", "ca", "dr", "dvs", "evt", "f.eks", "fr", "hr", "inkl", "jf", "kap", "kl", "m.fl", "m.m", "mht", "nr", "osv", "pga", "prof", "s", "st", "tlf", "vedr",
+		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "okt", "nov", "dec",
+	},
+	"de": {
+		"bzw", "ca", "d.h", "dr", "etc", "evtl", "fr", "ggf", "hr", "inkl", "jh", "nr", "o.ä", "prof", "s", "str", "u.a", "u.ä", "usw", "vgl", "z.b", "z.t",
+		"jan", "feb", "mär", "apr", "jun", "jul", "aug", "sep", "sept", "okt", "nov", "dez",
+	},
+	"en": {
+		"approx", "capt", "cf", "co", "col", "corp", "dept", "dr", "e.g", "est", "etc", "fig", "gen", "gov", "i.e", "inc", "jr", "lt", "ltd", "mr", "mrs", "ms", "mt", "no", "ph.d", "prof", "rev", "sgt", "sr", "st", "vol", "vs",
+		"a.m", "p.m", "u.k", "u.s",
+		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec",
+	},
+	"no": {
+		"bl.a", "ca", "dr", "dvs", "ev", "evt", "f.eks", "fr", "hr", "iflg", "inkl", "jf", "jfr", "kap", "kl", "m.fl", "m.m", "mht", "nr", "o.l", "osv", "pga", "prof", "s", "st", "tlf", "vha",
+		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "okt", "nov", "des",
+	},
+	"sv": {
+		"bl.a", "ca", "d.v.s", "dr", "dvs", "e.kr", "el", "ev", "exkl", "f.d", "f.kr", "f.ö", "fig", "fr.o.m", "inkl", "jfr", "kap", "kl", "m.a.o", "m.fl", "m.m", "nr", "o.d", "o.s.v", "obs", "osv", "p.g.a", "pga", "prof", "resp", "s", "s.k", "sid", "st", "t.ex", "t.h", "t.o.m", "t.v", "tel",
+		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "okt", "nov", "dec",
+	},
+}
+
+// AbbreviationLanguages returns the language codes of the built-in abbreviation lists, sorted
+func AbbreviationLanguages() []string {
+	res := []string{}
+	for lang := range Abbreviations {
+		res = append(res, lang)
+	}
+	sort.Strings(res)
+	return res
+}
+
+var (
+	urlRe    = regexp.MustCompile(`^(?i:(?:https?|ftp)://|www\.)[^\s<>"]+`)
+	emailRe  = regexp.MustCompile(`^[\p{L}\p{N}._%+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)*\.\p{L}{2,}`)
+	numberRe = regexp.MustCompile(`^\p{Nd}+(?:[.,:]\p{Nd}+)*`)
+	wordRe   = regexp.MustCompile(`^[\p{L}\p{M}\p{N}]+(?:['’-][\p{L}\p{M}\p{N}]+)*`)
+	abbrevRe = regexp.MustCompile(`^\p{L}+(?:\.\p{L}+)*\.`)
+	finalRe  = regexp.MustCompile(`^[.!?…]+`)
+)
+
+// closingPunctuation can follow sentence final punctuation within the sentence, as in: He said "Hi!" Then he left.
+const closingPunctuation = "\"'”’»)]}"
+
+// Segmenter is a rule-based sentence splitter and word tokenizer. Abbreviations (such as e.g. and Dr.) and single upper case letters followed by a period (initials) are kept as single tokens, and don't end a sentence (so a sentence ending with an abbreviation, such as etc., is not split from the next one). Numbers with decimals, URLs, e-mail addresses and ellipses are recognised as single tokens.
+type Segmenter struct {
+	abbreviations map[string]bool
+}
+
+// NewSegmenter creates a segmenter using the built-in abbreviation list of the language (see Abbreviations). Regional variants are accepted (en-GB uses the en list, and nb and nn use the no list). The empty language code creates a segmenter without abbreviations (except initials).
+func NewSegmenter(lang string) (*Segmenter, error) {
+	sg := &Segmenter{abbreviations: map[string]bool{}}
+	if lang == "" {
+		return sg, nil
+	}
+	parts := strings.FieldsFunc(lang, func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("invalid language code %q", lang)
+	}
+	base := strings.ToLower(parts[0])
+	if base == "nb" || base == "nn" {
+		base = "no"
+	}
+	abbrevs, ok := Abbreviations[base]
+	if !ok {
+		return nil, fmt.Errorf("no abbreviation list for language %s, expected one of: %s", lang, strings.Join(AbbreviationLanguages(), ", "))
+	}
+	sg.AddAbbreviations(abbrevs...)
+	return sg, nil
+}
+
+// AddAbbreviations adds abbreviations to the segmenter. The final period is optional, and case is ignored.
+func (sg *Segmenter) AddAbbreviations(abbrevs ...string) {
+	for _, a := range abbrevs {
+		sg.abbreviations[strings.ToLower(strings.TrimSuffix(a, "."))] = true
+	}
+}
+
+// ReadAbbreviations reads an abbreviation list from a file, with one abbreviation per line (see Segmenter.AddAbbreviations). Empty lines and lines starting with # are ignored.
+func ReadAbbreviations(fName string) ([]string, error) {
+	lines, err := io.ReadFileToLines(fName)
+	if err != nil {
+		return nil, err
+	}
+	res := []string{}
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		res = append(res, l)
+	}
+	return res, nil
+}
+
+// isAbbreviation returns true if the input (including the final period) is a known abbreviation or an initial
+func (sg *Segmenter) isAbbreviation(s string) bool {
+	w := strings.TrimSuffix(s, ".")
+	if r, size := utf8.DecodeRuneInString(w); size == len(w) && unicode.IsUpper(r) {
+		return true
+	}
+	return sg.abbreviations[strings.ToLower(w)]
+}
+
+// nextWord returns the kind and the byte length of the token at the start of the input string, which must not start with white space
+func (sg *Segmenter) nextWord(s string) (TokenKind, int) {
+	if m := urlRe.FindString(s); m != "" {
+		// trailing punctuation is not part of the URL, and neither are unbalanced closing parentheses
+		end := len(strings.TrimRight(m, ".,;:!?'\"’”"))
+		for strings.HasSuffix(m[:end], ")") && strings.Count(m[:end], ")") > strings.Count(m[:end], "(") {
+			end--
+		}
+		return URL, end
+	}
+	if m := emailRe.FindString(s); m != "" {
+		return Email, len(m)
+	}
+	if m := abbrevRe.FindString(s); m != "" && sg.isAbbreviation(m) {
+		return Abbreviation, len(m)
+	}
+	if m := numberRe.FindString(s); m != "" {
+		if r, _ := utf8.DecodeRuneInString(s[len(m):]); !isWordRune(r) {
+			return Number, len(m)
+		}
+	}
+	if m := wordRe.FindString(s); m != "" {
+		return Word, len(m)
+	}
+	if m := finalRe.FindString(s); m != "" {
+		return Punctuation, len(m)
+	}
+	g, _ := FirstGrapheme(s)
+	return Punctuation, len(g)
+}
+
+// Words splits the input string into word tokens: words, abbreviations, numbers, URLs, e-mail addresses and punctuation (see TokenKind), with their offsets. White space is not included in the output.
+func (sg *Segmenter) Words(s string) []Token {
+	res := []Token{}
+	pos, runePos := 0, 0
+	for pos < len(s) {
+		r, size := utf8.DecodeRuneInString(s[pos:])
+		if unicode.IsSpace(r) {
+			pos += size
+			runePos++
+			continue
+		}
+		kind, n := sg.nextWord(s[pos:])
+		str := s[pos : pos+n]
+		runeEnd := runePos + utf8.RuneCountInString(str)
+		res = append(res, Token{Kind: kind, String: str, Start: pos, End: pos + n, RuneStart: runePos, RuneEnd: runeEnd})
+		pos, runePos = pos+n, runeEnd
+	}
+	return res
+}
+
+// isSentenceEnd returns true if a sentence ends after the punctuation token tok, and any closing punctuation up to and including the token last, followed by the token next (nil at the end of the input). A sentence ends if the next token is separated by white space, and doesn't start with a lower case letter; after an ellipsis, the next token must start with an upper case letter.
+func isSentenceEnd(tok, last Token, next *Token) bool {
+	if next == nil {
+		return true
+	}
+	if next.Start == last.End {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(next.String)
+	if strings.Trim(tok.String, ".…") == "" && tok.String != "." {
+		return unicode.IsUpper(r)
+	}
+	return !unicode.IsLower(r)
+}
+
+// isParagraphBreak returns true if the input string contains an empty line
+func isParagraphBreak(s string) bool {
+	return strings.Count(s, "\n") >= 2
+}
+
+// Sentences splits the input string into sentences, with their offsets (see Segmenter). A sentence ends with sentence final punctuation (. ! ? or an ellipsis, followed by any closing quotes or brackets, see isSentenceEnd), or at an empty line. White space between sentences is not included in the output.
+func (sg *Segmenter) Sentences(s string) []Token {
+	res := []Token{}
+	words := sg.Words(s)
+	start := 0
+	add := func(end int) {
+		first, last := words[start], words[end]
+		res = append(res, Token{Kind: Sentence, String: s[first.Start:last.End], Start: first.Start, End: last.End, RuneStart: first.RuneStart, RuneEnd: last.RuneEnd})
+		start = end + 1
+	}
+	for i := 0; i < len(words); i++ {
+		tok := words[i]
+		if tok.Kind == Punctuation && strings.ContainsAny(tok.String, ".!?…") {
+			for i+1 < len(words) && words[i+1].Start == words[i].End && strings.Contains(closingPunctuation, words[i+1].String) {
+				i++
+			}
+			var next *Token
+			if i+1 < len(words) {
+				next = &words[i+1]
+			}
+			if isSentenceEnd(tok, words[i], next) {
+				add(i)
+				continue
+			}
+		}
+		if i+1 < len(words) && isParagraphBreak(s[words[i].End:words[i+1].Start]) {
+			add(i)
+		}
+	}
+	if start < len(words) {
+		add(len(words) - 1)
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package strings
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSegmenterWords(t *testing.T) {
+	var test = func(lang, in string, exp []string, expKinds []TokenKind) {
+		sg, err := NewSegmenter(lang)
+		if err != nil {
+			t.Fatalf("Got error from NewSegmenter: %v", err)
+		}
+		got, gotKinds := []string{}, []TokenKind{}
+		for _, tok := range sg.Words(in) {
+			got = append(got, tok.String)
+			gotKinds = append(gotKinds, tok.Kind)
+			if in[tok.Start:tok.End] != tok.String {
+				t.Errorf("offsets of %q don't match the input: %d-%d", tok.String, tok.Start, tok.End)
+			}
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+		if expKinds != nil && !reflect.DeepEqual(gotKinds, expKinds) {
+			t.Errorf(fsExpGot, expKinds, gotKinds)
+		}
+	}
+	test("en", "", []string{}, nil)
+	test("en", "Hello, world!", []string{"Hello", ",", "world", "!"}, []TokenKind{Word, Punctuation, Word, Punctuation})
+	test("en", "Dr. Smith paid $3.50, e.g. 1,000.5 USD.", []string{"Dr.", "Smith", "paid", "$", "3.50", ",", "e.g.", "1,000.5", "USD", "."},
+		[]TokenKind{Abbreviation, Word, Word, Punctuation, Number, Punctuation, Abbreviation, Number, Word, Punctuation})
+	test("en", "See https://example.com/path?q=1. Or www.example.com!", []string{"See", "https://example.com/path?q=1", ".", "Or", "www.example.com", "!"},
+		[]TokenKind{Word, URL, Punctuation, Word, URL, Punctuation})
+	test("en", "(https://example.com/a)", []string{"(", "https://example.com/a", ")"}, nil)
+	test("en", "(see https://en.wikipedia.org/wiki/Go_(game))", []string{"(", "see", "https://en.wikipedia.org/wiki/Go_(game)", ")"}, nil)
+	test("en", "Mail anna.b@example.co.uk now", []string{"Mail", "anna.b@example.co.uk", "now"}, []TokenKind{Word, Email, Word})
+	test("en", "Well... I don't know…", []string{"Well", "...", "I", "don't", "know", "…"}, nil)
+	test("en", "What?! A well-known 1990s hit", []string{"What", "?!", "A", "well-known", "1990s", "hit"}, nil)
+	test("sv", "T.ex. bl.a. Anna", []string{"T.ex.", "bl.a.", "Anna"}, []TokenKind{Abbreviation, Abbreviation, Word})
+	test("", "t.ex. bl.a.", []string{"t", ".", "ex", ".", "bl", ".", "a", "."}, nil)
+	test("en", "👍🏽 ok", []string{"👍🏽", "ok"}, []TokenKind{Punctuation, Word})
+}
+
+func TestSegmenterSentences(t *testing.T) {
+	var test = func(lang, in string, exp []string) {
+		sg, err := NewSegmenter(lang)
+		if err != nil {
+			t.Fatalf("Got error from NewSegmenter: %v", err)
+		}
+		got := []string{}
+		for _, tok := range sg.Sentences(in) {
+			got = append(got, tok.String)
+			if in[tok.Start:tok.End] != tok.String {
+				t.Errorf("offsets of %q don't match the input: %d-%d", tok.String, tok.Start, tok.End)
+			}
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("en", "", []string{})
+	test("en", "  ", []string{})
+	test("en", "One. Two! Three? Four", []string{"One.", "Two!", "Three?", "Four"})
+	test("en", "Mr. Smith paid 3.50 for it. Then he left.", []string{"Mr. Smith paid 3.50 for it.", "Then he left."})
+	test("en", "J. R. R. Tolkien wrote it. It is long.", []string{"J. R. R. Tolkien wrote it.", "It is long."})
+	test("en", `He said "Hi!" Then he left.`, []string{`He said "Hi!"`, "Then he left."})
+	test("en", "Go to www.example.com. It is good.", []string{"Go to www.example.com.", "It is good."})
+	test("en", "Wait... what? Hmm… Okay.", []string{"Wait... what?", "Hmm…", "Okay."})
+	test("en", "It's 5 p.m. now. Then more.", []string{"It's 5 p.m. now.", "Then more."})
+	test("en", "See file.txt for details. Done.", []string{"See file.txt for details.", "Done."})
+	test("en", "A heading\n\nThe text\ncontinues here.", []string{"A heading", "The text\ncontinues here."})
+	test("sv", "Han åt t.ex. äpplen. Sedan gick han.", []string{"Han åt t.ex. äpplen.", "Sedan gick han."})
+	test("sv", "Se t.ex. Anna. Eller Per.", []string{"Se t.ex. Anna.", "Eller Per."})
+	test("", "Se t.ex. Anna. Eller Per.", []string{"Se t.ex.", "Anna.", "Eller Per."})
+	test("en", `He said "Hi!"Then`, []string{`He said "Hi!"Then`})
+}
+
+func TestSegmenterOffsets(t *testing.T) {
+	sg, _ := NewSegmenter("en")
+	got := sg.Sentences("Ärligt. Öl?")
+	exp := []Token{
+		{Kind: Sentence, String: "Ärligt.", Start: 0, End: 8, RuneStart: 0, RuneEnd: 7},
+		{Kind: Sentence, String: "Öl?", Start: 9, End: 13, RuneStart: 8, RuneEnd: 11},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestSegmenterAbbreviations(t *testing.T) {
+	if _, err := NewSegmenter("xx"); err == nil {
+		t.Errorf("Expected error for unknown language")
+	}
+	for _, lang := range []string{"-", "_", "-_"} {
+		if _, err := NewSegmenter(lang); err == nil {
+			t.Errorf("Expected error for invalid language code %q", lang)
+		}
+	}
+	for _, lang := range []string{"en-GB", "nb", "sv_SE"} {
+		if _, err := NewSegmenter(lang); err != nil {
+			t.Errorf("Got error from NewSegmenter(%s): %v", lang, err)
+		}
+	}
+
+	fName := filepath.Join(t.TempDir(), "abbrevs.txt")
+	if err := os.WriteFile(fName, []byte("# extra\nSthlm.\n\nkr\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	abbrevs, err := ReadAbbreviations(fName)
+	if err != nil {
+		t.Fatalf("Got error from ReadAbbreviations: %v", err)
+	}
+	if exp := []string{"Sthlm.", "kr"}; !reflect.DeepEqual(abbrevs, exp) {
+		t.Errorf(fsExpGot, exp, abbrevs)
+	}
+	sg, _ := NewSegmenter("sv")
+	sg.AddAbbreviations(abbrevs...)
+	got := []string{}
+	for _, tok := range sg.Sentences("Det kostar 5 kr. Per person i sthlm. Och Göteborg.") {
+		got = append(got, tok.String)
+	}
+	if exp := []string{"Det kostar 5 kr. Per person i sthlm. Och Göteborg."}; !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
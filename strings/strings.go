@@ -2,30 +2,20 @@ package strings
 
 import (
 	"strings"
+
+	"golang.org/x/text/language"
 )
 
-// UpcaseInitial Upcase the first rune of a string, and optionally downcase the remainder
+// UpcaseInitial Upcase the first rune of a string, and optionally downcase the remainder. The casing is language independent; see UpcaseInitialLang for language specific casing.
 func UpcaseInitial(s string, downcaseRemainder bool) string {
-	runes := []rune(s)
-	head := ""
-	if len(runes) > 0 {
-		head = strings.ToUpper(string(runes[0]))
-	}
-	tail := ""
-	if len(runes) > 0 {
-		tail = string(runes[1:])
-		if downcaseRemainder {
-			tail = strings.ToLower(tail)
-		}
-	}
-	return head + tail
+	return UpcaseInitialLang(s, downcaseRemainder, language.Und)
 }
 
-// Reverse a string
+// Reverse a string, grapheme cluster by grapheme cluster, so that combining marks, emoji sequences, flags etc are kept intact (see Graphemes and ReverseRunes)
 func Reverse(str string) string {
-	bts := []rune(str)
-	for i, j := 0, len(bts)-1; i < j; i, j = i+1, j-1 {
-		bts[i], bts[j] = bts[j], bts[i]
+	gs := Graphemes(str)
+	for i, j := 0, len(gs)-1; i < j; i, j = i+1, j-1 {
+		gs[i], gs[j] = gs[j], gs[i]
 	}
-	return string(bts)
+	return strings.Join(gs, "")
 }
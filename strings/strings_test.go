@@ -30,4 +30,15 @@ func TestUpcaseInitial(t *testing.T) {
 	test("annA", "AnnA", false)
 	test("street Feet", "Street Feet", false)
 	test("street Feet", "Street feet", true)
+
+	// the first rune is upcased rune by rune, not title cased or expanded
+	test("ßtraße", "ßtraße", false)
+	test("ǆem", "Ǆem", false)
+	test("ﬁne", "ﬁne", false)
+	test("ﬂOW", "ﬂow", true)
+	test("ŉa", "ŉa", false)
+
+	// the remainder is downcased rune by rune, without final sigma or expansion
+	test("aBΣ", "Abσ", true)
+	test("aİ", "Ai", true)
 }
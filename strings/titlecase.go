@@ -0,0 +1,195 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+// Stopwords holds the built-in lists of words that are kept in lower case by the title caser, by base language code (Norwegian is keyed no, and used for nb and nn as well)
+var Stopwords = map[string][]string{
+	"en": {"a", "an", "and", "as", "at", "but", "by", "for", "from", "in", "into", "nor", "of", "off", "on", "or", "per", "so", "the", "to", "up", "via", "vs", "with", "yet"},
+	"sv": {"av", "eller", "en", "ett", "för", "från", "i", "med", "och", "om", "på", "som", "till", "vid", "åt"},
+	"no": {"av", "eller", "en", "et", "for", "fra", "i", "med", "og", "om", "på", "som", "til", "ved"},
+	"da": {"af", "eller", "en", "et", "for", "fra", "i", "med", "og", "om", "på", "som", "til", "ved"},
+	"de": {"am", "an", "auf", "aus", "bei", "das", "dem", "den", "der", "des", "die", "ein", "eine", "für", "im", "in", "mit", "oder", "und", "von", "vom", "zu", "zum", "zur"},
+	"nl": {"aan", "de", "een", "en", "het", "in", "met", "of", "op", "te", "van", "voor"},
+	"fr": {"à", "au", "aux", "dans", "de", "des", "du", "en", "et", "la", "le", "les", "ou", "par", "pour", "sur", "un", "une"},
+	"es": {"a", "con", "de", "del", "el", "en", "la", "las", "los", "o", "para", "por", "un", "una", "y"},
+	"it": {"a", "con", "da", "di", "del", "della", "e", "gli", "il", "in", "la", "le", "lo", "o", "per", "un", "una"},
+}
+
+// TitleCaser converts strings to title case: the initial letter of each word is upcased, except for stopwords. Words are separated by white space, punctuation and hyphens, so each part of a hyphenated compound is handled as a word (mother-in-law => Mother-in-Law). The first and the last word are always upcased.
+type TitleCaser struct {
+	// Lang is the language used for casing (see UpcaseInitialLang)
+	Lang language.Tag
+	// Stopwords are words (in lower case) kept in lower case unless first or last, such as of and the; matched case insensitively
+	Stopwords map[string]bool
+	// Prefixes are name prefixes after which the next letter is upcased as well, such as Mc (mcdonald => McDonald) and O' (o'brien => O'Brien); matched case insensitively
+	Prefixes []string
+	// PrefixExceptions are words that start with a prefix, but are not names, such as o'clock; they are title cased as other words (o'clock => O'clock). Matched case insensitively.
+	PrefixExceptions []string
+	// LowerPrefixes are prefixes kept in lower case (unless in the first word), with the next letter upcased, such as d' (d'artagnan => d'Artagnan)
+	LowerPrefixes []string
+	// PreserveAcronyms keeps all upper case words of two or more letters as is, such as NASA and EU
+	PreserveAcronyms bool
+	// DowncaseRemainder downcases the rest of each word (NASA is still kept as is, if PreserveAcronyms is set)
+	DowncaseRemainder bool
+}
+
+// NewTitleCaser creates a title caser with the default settings for the language: the built-in stopwords for the language (see Stopwords), the prefixes Mc and O' (except in o'clock and o'er), the lower case prefixes d' and l' (for French and Italian), and preserved acronyms
+func NewTitleCaser(lang language.Tag) TitleCaser {
+	b, _ := lang.Base()
+	base := b.String()
+	if base == "nb" || base == "nn" {
+		base = "no"
+	}
+	tc := TitleCaser{
+		Lang:             lang,
+		Stopwords:        map[string]bool{},
+		Prefixes:         []string{"Mc", "O'"},
+		PrefixExceptions: []string{"o'clock", "o'er"},
+		LowerPrefixes:    []string{"d'"},
+		PreserveAcronyms: true,
+	}
+	for _, w := range Stopwords[base] {
+		tc.Stopwords[w] = true
+	}
+	if base == "fr" || base == "it" {
+		tc.LowerPrefixes = append(tc.LowerPrefixes, "l'")
+	}
+	return tc
+}
+
+// isWordRune returns true for runes that are part of a word: letters, marks and digits
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+type titleSegment struct {
+	s      string
+	isWord bool
+}
+
+// titleSegments splits the input into words and separators. Apostrophes between word runes are part of the word (O'Brien, d'Artagnan).
+func titleSegments(s string) []titleSegment {
+	res := []titleSegment{}
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		isWord := isWordRune(runes[i])
+		j := i + 1
+		for j < len(runes) {
+			if isWord && isApostrophe(runes[j]) && j+1 < len(runes) && isWordRune(runes[j+1]) {
+				j++
+				continue
+			}
+			if isWordRune(runes[j]) != isWord {
+				break
+			}
+			j++
+		}
+		seg := string(runes[i:j])
+		res = append(res, titleSegment{s: seg, isWord: isWord})
+		i = j
+	}
+	return res
+}
+
+// normApostrophes replaces typographic apostrophes with ', for prefix matching
+func normApostrophes(s string) string {
+	return strings.ReplaceAll(s, "’", "'")
+}
+
+// hasPrefixFold returns true if s starts with the prefix, ignoring case and apostrophe variants, and has letters after the prefix
+func (tc TitleCaser) hasPrefixFold(s, prefix string) bool {
+	norm := normApostrophes(ToLower(s, tc.Lang))
+	prefix = normApostrophes(ToLower(prefix, tc.Lang))
+	return len(norm) > len(prefix) && strings.HasPrefix(norm, prefix)
+}
+
+// splitPrefix splits the word after the prefix (the prefix length may differ in bytes, for typographic apostrophes)
+func splitPrefix(w, prefix string) (string, string) {
+	n := utf8.RuneCountInString(prefix)
+	runes := []rune(w)
+	return string(runes[:n]), string(runes[n:])
+}
+
+// isPrefixException returns true if the word is one of the prefix exceptions, ignoring case and apostrophe variants
+func (tc TitleCaser) isPrefixException(w string) bool {
+	norm := normApostrophes(ToLower(w, tc.Lang))
+	for _, e := range tc.PrefixExceptions {
+		if norm == normApostrophes(ToLower(e, tc.Lang)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAcronym(w string) bool {
+	nLetters := 0
+	for _, r := range w {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			nLetters++
+		}
+	}
+	return nLetters >= 2
+}
+
+func (tc TitleCaser) titleWord(w string, first, last bool) string {
+	if tc.PreserveAcronyms && isAcronym(w) {
+		return w
+	}
+	lower := ToLower(w, tc.Lang)
+	if !first && !last && tc.Stopwords[lower] {
+		return lower
+	}
+	for _, p := range tc.LowerPrefixes {
+		if tc.hasPrefixFold(w, p) {
+			head, tail := splitPrefix(w, p)
+			if first {
+				head = UpcaseInitialLang(head, true, tc.Lang)
+			} else {
+				head = ToLower(head, tc.Lang)
+			}
+			return head + UpcaseInitialLang(tail, tc.DowncaseRemainder, tc.Lang)
+		}
+	}
+	for _, p := range tc.Prefixes {
+		if tc.hasPrefixFold(w, p) && !tc.isPrefixException(w) {
+			head, tail := splitPrefix(w, p)
+			return UpcaseInitialLang(head, true, tc.Lang) + UpcaseInitialLang(tail, tc.DowncaseRemainder, tc.Lang)
+		}
+	}
+	return UpcaseInitialLang(w, tc.DowncaseRemainder, tc.Lang)
+}
+
+// Title converts the input string to title case, e.g. "the lord of the rings" => "The Lord of the Rings"
+func (tc TitleCaser) Title(s string) string {
+	segs := titleSegments(s)
+	nWords := 0
+	for _, seg := range segs {
+		if seg.isWord {
+			nWords++
+		}
+	}
+	var res strings.Builder
+	wi := 0
+	for _, seg := range segs {
+		if !seg.isWord {
+			res.WriteString(seg.s)
+			continue
+		}
+		res.WriteString(tc.titleWord(seg.s, wi == 0, wi == nWords-1))
+		wi++
+	}
+	return res.String()
+}
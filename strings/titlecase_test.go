@@ -0,0 +1,47 @@
+package strings
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestTitleCaser(t *testing.T) {
+	var test = func(tc TitleCaser, in, exp string) {
+		if got := tc.Title(in); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	en := NewTitleCaser(language.English)
+	test(en, "", "")
+	test(en, "the lord of the rings", "The Lord of the Rings")
+	test(en, "what it is for", "What It Is For")
+	test(en, "ronald mcdonald and o'brien", "Ronald McDonald and O'Brien")
+	test(en, "o’neill", "O’Neill")
+	test(en, "five o'clock tea", "Five O'clock Tea")
+	test(en, "a mother-in-law joke", "A Mother-in-Law Joke")
+	test(en, "jean-luc picard", "Jean-Luc Picard")
+	test(en, "news from NASA and the EU", "News from NASA and the EU")
+	test(en, "  (the end)  ", "  (The End)  ")
+	test(en, "A", "A")
+
+	en.DowncaseRemainder = true
+	test(en, "THE LORD OF THE RINGS", "THE LORD OF THE RINGS")
+	en.PreserveAcronyms = false
+	test(en, "THE LORD OF THE RINGS", "The Lord of the Rings")
+	test(en, "MCDONALD", "McDonald")
+
+	fr := NewTitleCaser(language.French)
+	test(fr, "les aventures de d'artagnan", "Les Aventures de d'Artagnan")
+	test(fr, "l'étranger et l'avion", "L'Étranger et l'Avion")
+
+	sv := NewTitleCaser(language.Swedish)
+	test(sv, "sagan om ringen", "Sagan om Ringen")
+
+	for _, lang := range []language.Tag{language.Norwegian, language.MustParse("nb"), language.MustParse("nn")} {
+		test(NewTitleCaser(lang), "reise til og fra oslo", "Reise til og fra Oslo")
+	}
+
+	tr := NewTitleCaser(language.Turkish)
+	test(tr, "istanbul ve izmir", "İstanbul Ve İzmir")
+}
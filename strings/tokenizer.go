@@ -2,51 +2,130 @@ package strings
 
 import (
 	"fmt"
-	"strings"
-	//"os"
 	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenKind is the kind of a token: word or delimiter for a RegexpTokenizer, and word, abbreviation, number, URL, e-mail address, punctuation or sentence for a Segmenter
+type TokenKind int
+
+const (
+	// Word is a token between delimiters
+	Word TokenKind = iota
+	// Delimiter is a token matching the delimiter definition
+	Delimiter
+	// Abbreviation is a word including its final period, such as e.g. or Dr.
+	Abbreviation
+	// Number is a number, possibly including decimal and thousands separators, such as 3.14 or 1,000
+	Number
+	// URL is a web address, such as https://example.com/ or www.example.com
+	URL
+	// Email is an e-mail address
+	Email
+	// Punctuation is a punctuation mark or other symbol, or a sequence of sentence final punctuation, such as ?! or an ellipsis
+	Punctuation
+	// Sentence is a sentence
+	Sentence
 )
 
+func (k TokenKind) String() string {
+	switch k {
+	case Word:
+		return "word"
+	case Delimiter:
+		return "delimiter"
+	case Abbreviation:
+		return "abbreviation"
+	case Number:
+		return "number"
+	case URL:
+		return "url"
+	case Email:
+		return "email"
+	case Punctuation:
+		return "punctuation"
+	case Sentence:
+		return "sentence"
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// Token is a token from a RegexpTokenizer or a Segmenter, with its position in the input string
+type Token struct {
+	// Kind is the token kind (word or delimiter)
+	Kind TokenKind
+	// String is the token string
+	String string
+	// Start is the byte offset of the token in the input string
+	Start int
+	// End is the byte offset of the end of the token in the input string (exclusive)
+	End int
+	// RuneStart is the rune offset of the token in the input string
+	RuneStart int
+	// RuneEnd is the rune offset of the end of the token in the input string (exclusive)
+	RuneEnd int
+}
+
 // RegexpTokenizer is a simple tokenizer using a regexp as a delimiter definition
 type RegexpTokenizer struct {
 	delimRE *regexp.Regexp
 }
 
-// Split the input string using the specified `delimRE` delimiter definition. Returns a slice of string tokens (including delimiter tokens).
-func (t RegexpTokenizer) Split(s string) []string {
-	if len(s) == 0 {
-		return []string{}
+// NewRegexpTokenizer creates a tokenizer using the pattern as delimiter definition, e.g. `[ .,/()&#!?]+`
+func NewRegexpTokenizer(pattern string) (RegexpTokenizer, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return RegexpTokenizer{}, fmt.Errorf("invalid delimiter pattern %s : %v", pattern, err)
 	}
-	ms := t.delimRE.FindAllStringIndex(s, -1)
-	if ms == nil || len(ms) == 0 {
-		return []string{s}
+	return RegexpTokenizer{delimRE: re}, nil
+}
+
+// Tokenize the input string using the delimiter definition. Returns a slice of tokens (including delimiter tokens), with their kinds and offsets. Empty delimiter matches are ignored. An error is returned if the tokens don't add up to the input string (which should never happen).
+func (t RegexpTokenizer) Tokenize(s string) ([]Token, error) {
+	res := []Token{}
+	if t.delimRE == nil {
+		return res, fmt.Errorf("no delimiter definition (use NewRegexpTokenizer to create a tokenizer)")
 	}
-	splitPoints := map[int]bool{}
-	for _, m := range ms {
-		splitPoints[m[0]] = true
-		splitPoints[m[1]] = true
+	pos, runePos := 0, 0
+	var add = func(kind TokenKind, end int) {
+		str := s[pos:end]
+		runeEnd := runePos + utf8.RuneCountInString(str)
+		res = append(res, Token{Kind: kind, String: str, Start: pos, End: end, RuneStart: runePos, RuneEnd: runeEnd})
+		pos, runePos = end, runeEnd
 	}
-	//fmt.Fprintf(os.Stderr, "%v\n", splitPoints)
-	res := []string{}
-	acc := []rune{}
-	for i, r := range s {
-		//fmt.Fprintf(os.Stderr, "<%s> %s %v %v\n", string(acc), string(r), i, splitPoints[i])
-		if splitPoints[i] {
-			if len(acc) > 0 {
-				res = append(res, string(acc))
-				acc = []rune{}
-			}
+	for _, m := range t.delimRE.FindAllStringIndex(s, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+		if m[0] > pos {
+			add(Word, m[0])
 		}
-		acc = append(acc, r)
+		add(Delimiter, m[1])
+	}
+	if pos < len(s) {
+		add(Word, len(s))
+	}
+
+	var test strings.Builder
+	for _, tok := range res {
+		test.WriteString(tok.String)
 	}
-	if len(acc) > 0 {
-		res = append(res, string(acc))
-		acc = []rune{}
+	if test.String() != s {
+		return res, fmt.Errorf("tokenized string must match input; expected <%s>, got <%s>", s, test.String())
 	}
-	test := strings.Join(res, "")
-	if test != s {
-		msg := fmt.Sprintf("Tokenized string must match input; expected <%s>, got <%s>", s, test)
-		panic(msg)
+	return res, nil
+}
+
+// Split the input string using the specified `delimRE` delimiter definition. Returns a slice of string tokens (including delimiter tokens). See also Tokenize.
+func (t RegexpTokenizer) Split(s string) ([]string, error) {
+	toks, err := t.Tokenize(s)
+	if err != nil {
+		return []string{}, err
+	}
+	res := []string{}
+	for _, tok := range toks {
+		res = append(res, tok.String)
 	}
-	return res
+	return res, nil
 }
@@ -2,20 +2,23 @@ package strings
 
 import (
 	"fmt"
-	"regexp"
+	"reflect"
+	"testing"
 )
 
 func ExampleRegexpTokenizer_t1() {
-	tk := RegexpTokenizer{delimRE: regexp.MustCompile(`[ .,/()&#!?]+`)}
-	for _, w := range tk.Split("") {
+	tk, _ := NewRegexpTokenizer(`[ .,/()&#!?]+`)
+	ws, _ := tk.Split("")
+	for _, w := range ws {
 		fmt.Printf("<%s>\n", w)
 	}
 	// Output:
 }
 
 func ExampleRegexpTokenizer_t2() {
-	tk := RegexpTokenizer{delimRE: regexp.MustCompile(`[ .,/()&#!?]+`)}
-	for _, w := range tk.Split(" ") {
+	tk, _ := NewRegexpTokenizer(`[ .,/()&#!?]+`)
+	ws, _ := tk.Split(" ")
+	for _, w := range ws {
 		fmt.Printf("<%s>\n", w)
 	}
 	// Output:
@@ -23,8 +26,9 @@ func ExampleRegexpTokenizer_t2() {
 }
 
 func ExampleRegexpTokenizer_t3() {
-	tk := RegexpTokenizer{delimRE: regexp.MustCompile(`[ .,/()&#!?]+`)}
-	for _, w := range tk.Split("hej du") {
+	tk, _ := NewRegexpTokenizer(`[ .,/()&#!?]+`)
+	ws, _ := tk.Split("hej du")
+	for _, w := range ws {
 		fmt.Printf("<%s>\n", w)
 	}
 	// Output:
@@ -34,8 +38,9 @@ func ExampleRegexpTokenizer_t3() {
 }
 
 func ExampleRegexpTokenizer_t4() {
-	tk := RegexpTokenizer{delimRE: regexp.MustCompile(`[ .,/()&#!?]+`)}
-	for _, w := range tk.Split(" -s") {
+	tk, _ := NewRegexpTokenizer(`[ .,/()&#!?]+`)
+	ws, _ := tk.Split(" -s")
+	for _, w := range ws {
 		fmt.Printf("<%s>\n", w)
 	}
 	// Output:
@@ -44,8 +49,9 @@ func ExampleRegexpTokenizer_t4() {
 }
 
 func ExampleRegexpTokenizer_t5() {
-	tk := RegexpTokenizer{delimRE: regexp.MustCompile(`[ .,/()&#!?]+`)}
-	for _, w := range tk.Split("jag-är!& -en liten apa") {
+	tk, _ := NewRegexpTokenizer(`[ .,/()&#!?]+`)
+	ws, _ := tk.Split("jag-är!& -en liten apa")
+	for _, w := range ws {
 		fmt.Printf("<%s>\n", w)
 		//fmt.Fprintf(os.Stderr, "<%s>\n", w)
 	}
@@ -60,8 +66,9 @@ func ExampleRegexpTokenizer_t5() {
 }
 
 func ExampleRegexpTokenizer_t6() {
-	tk := RegexpTokenizer{delimRE: regexp.MustCompile(`[ .,/()&#!?]+`)}
-	for _, w := range tk.Split("jag-är!& -en liten apa!") {
+	tk, _ := NewRegexpTokenizer(`[ .,/()&#!?]+`)
+	ws, _ := tk.Split("jag-är!& -en liten apa!")
+	for _, w := range ws {
 		fmt.Printf("<%s>\n", w)
 		//fmt.Fprintf(os.Stderr, "<%s>\n", w)
 	}
@@ -75,3 +82,38 @@ func ExampleRegexpTokenizer_t6() {
 	// <apa>
 	// <!>
 }
+
+func ExampleRegexpTokenizer_Tokenize() {
+	tk, _ := NewRegexpTokenizer(`[ .,!?]+`)
+	toks, _ := tk.Tokenize("Hej, världen!")
+	for _, t := range toks {
+		fmt.Printf("%-9s <%s> bytes %d-%d runes %d-%d\n", t.Kind, t.String, t.Start, t.End, t.RuneStart, t.RuneEnd)
+	}
+	// Output:
+	// word      <Hej> bytes 0-3 runes 0-3
+	// delimiter <, > bytes 3-5 runes 3-5
+	// word      <världen> bytes 5-13 runes 5-12
+	// delimiter <!> bytes 13-14 runes 12-13
+}
+
+func TestNewRegexpTokenizer(t *testing.T) {
+	if _, err := NewRegexpTokenizer(`[a-`); err == nil {
+		t.Errorf("Expected error for invalid pattern")
+	}
+	if _, err := (RegexpTokenizer{}).Tokenize("a b"); err == nil {
+		t.Errorf("Expected error for uninitialised tokenizer")
+	}
+
+	// empty delimiter matches are ignored
+	tk, err := NewRegexpTokenizer(`,*`)
+	if err != nil {
+		t.Fatalf("Got error from NewRegexpTokenizer: %v", err)
+	}
+	got, err := tk.Split("a,,b")
+	if err != nil {
+		t.Errorf("Got error from Split: %v", err)
+	}
+	if exp := []string{"a", ",,", "b"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
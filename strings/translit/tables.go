@@ -0,0 +1,224 @@
+package translit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Table is a named set of transliteration rules
+type Table struct {
+	// Name is the table name, such as iso9 or bgn-pcgn-ru
+	Name string
+	// Description is a one-line description of the table
+	Description string
+	// Rules are the transliteration rules
+	Rules []Rule
+}
+
+// New creates a transliterator from the table rules
+func (t Table) New() (*Transliterator, error) {
+	return New(t.Rules)
+}
+
+// pairs creates context free rules from a list of alternating input and output strings
+func pairs(fromTo ...string) []Rule {
+	res := []Rule{}
+	for i := 0; i+1 < len(fromTo); i += 2 {
+		res = append(res, Rule{From: fromTo[i], To: fromTo[i+1]})
+	}
+	return res
+}
+
+// join concatenates lists of rules
+func join(rules ...[]Rule) []Rule {
+	res := []Rule{}
+	for _, rs := range rules {
+		res = append(res, rs...)
+	}
+	return res
+}
+
+// Cyrillic
+
+var iso9 = pairs(
+	"а", "a", "б", "b", "в", "v", "г", "g", "ґ", "g̀", "д", "d", "ѓ", "ǵ", "ђ", "đ",
+	"е", "e", "ё", "ë", "є", "ê", "ж", "ž", "з", "z", "ѕ", "ẑ", "и", "i", "і", "ì",
+	"ї", "ï", "й", "j", "ј", "ǰ", "к", "k", "ќ", "ḱ", "л", "l", "љ", "l̂", "м", "m",
+	"н", "n", "њ", "n̂", "о", "o", "п", "p", "р", "r", "с", "s", "т", "t", "ћ", "ć",
+	"у", "u", "ў", "ŭ", "ф", "f", "х", "h", "ц", "c", "ч", "č", "џ", "d̂", "ш", "š",
+	"щ", "ŝ", "ъ", "ʺ", "ы", "y", "ь", "ʹ", "э", "è", "ю", "û", "я", "â", "ѣ", "ě",
+	"ѳ", "f̀", "ѵ", "ỳ",
+)
+
+// bgnRussianVowelContext is the preceding context where е and ё are written with an initial y in BGN/PCGN: word start, vowels, й, ъ and ь
+const bgnRussianVowelContext = "^аеёиоуыэюяйъь"
+
+var bgnPCGNRussian = join(
+	[]Rule{
+		{From: "е", To: "ye", Preceding: bgnRussianVowelContext},
+		{From: "ё", To: "yë", Preceding: bgnRussianVowelContext},
+	},
+	pairs(
+		"а", "a", "б", "b", "в", "v", "г", "g", "д", "d", "е", "e", "ё", "ë", "ж", "zh",
+		"з", "z", "и", "i", "й", "y", "к", "k", "л", "l", "м", "m", "н", "n", "о", "o",
+		"п", "p", "р", "r", "с", "s", "т", "t", "у", "u", "ф", "f", "х", "kh", "ц", "ts",
+		"ч", "ch", "ш", "sh", "щ", "shch", "ъ", "ʺ", "ы", "y", "ь", "ʹ", "э", "e", "ю", "yu",
+		"я", "ya",
+	),
+)
+
+var alaLCRussian = pairs(
+	"а", "a", "б", "b", "в", "v", "г", "g", "д", "d", "е", "e", "ё", "ë", "ж", "zh",
+	"з", "z", "и", "i", "й", "ĭ", "к", "k", "л", "l", "м", "m", "н", "n", "о", "o",
+	"п", "p", "р", "r", "с", "s", "т", "t", "у", "u", "ф", "f", "х", "kh", "ц", "t͡s",
+	"ч", "ch", "ш", "sh", "щ", "shch", "ъ", "ʺ", "ы", "y", "ь", "ʹ", "э", "ė", "ю", "i͡u",
+	"я", "i͡a",
+)
+
+// Greek
+
+// greekVoicelessContext is the following context where αυ, ευ and ηυ are pronounced (and romanised) with f instead of v: voiceless consonants and word end
+const greekVoicelessContext = "θκξπστφχψ$"
+
+var bgnPCGNGreek = join(
+	[]Rule{
+		{From: "αυ", To: "af", Following: greekVoicelessContext},
+		{From: "ευ", To: "ef", Following: greekVoicelessContext},
+		{From: "ηυ", To: "if", Following: greekVoicelessContext},
+		{From: "αύ", To: "áf", Following: greekVoicelessContext},
+		{From: "εύ", To: "éf", Following: greekVoicelessContext},
+		{From: "ηύ", To: "íf", Following: greekVoicelessContext},
+		{From: "γκ", To: "g", Preceding: "^"},
+		{From: "μπ", To: "b", Preceding: "^"},
+		{From: "ντ", To: "d", Preceding: "^"},
+	},
+	pairs(
+		"αυ", "av", "ευ", "ev", "ηυ", "iv", "αύ", "áv", "εύ", "év", "ηύ", "ív",
+		"αϋ", "aÿ", "εϋ", "eÿ", "ηϋ", "iÿ", "οϋ", "oÿ",
+		"αι", "ai", "ει", "ei", "οι", "oi", "ου", "ou", "υι", "yi",
+		"γγ", "ng", "γκ", "ng", "γξ", "nx", "γχ", "nch", "μπ", "mb", "ντ", "nd",
+		"α", "a", "β", "v", "γ", "g", "δ", "d", "ε", "e", "ζ", "z", "η", "i", "θ", "th",
+		"ι", "i", "κ", "k", "λ", "l", "μ", "m", "ν", "n", "ξ", "x", "ο", "o", "π", "p",
+		"ρ", "r", "σ", "s", "ς", "s", "τ", "t", "υ", "y", "φ", "f", "χ", "ch", "ψ", "ps",
+		"ω", "o",
+	),
+)
+
+var alaLCGreek = join(
+	[]Rule{
+		{From: "μπ", To: "b", Preceding: "^"},
+		{From: "ντ", To: "d", Preceding: "^"},
+	},
+	pairs(
+		// accents and breathings are not retained, except for the rough breathing on rho
+		"́", "", "̀", "", "͂", "", "̓", "", "̔", "", "ͅ", "",
+		"ῥ", "rh",
+		"αυ", "au", "ευ", "eu", "ηυ", "ēu", "ου", "ou", "υι", "ui",
+		"γγ", "ng", "γκ", "nk", "γξ", "nx", "γχ", "nch", "μπ", "mp", "ντ", "nt",
+		"α", "a", "β", "b", "γ", "g", "δ", "d", "ε", "e", "ζ", "z", "η", "ē", "θ", "th",
+		"ι", "i", "κ", "k", "λ", "l", "μ", "m", "ν", "n", "ξ", "x", "ο", "o", "π", "p",
+		"ρ", "r", "σ", "s", "ς", "s", "τ", "t", "υ", "y", "φ", "ph", "χ", "ch", "ψ", "ps",
+		"ω", "ō",
+	),
+)
+
+// Arabic
+
+const (
+	fatha    = "َ"
+	damma    = "ُ"
+	kasra    = "ِ"
+	fathatan = "ً"
+	dammatan = "ٌ"
+	kasratan = "ٍ"
+	shadda   = "ّ"
+	sukun    = "ْ"
+)
+
+// arabicConsonants holds the consonants that differ between BGN/PCGN and ALA-LC, as Arabic letter, BGN/PCGN and ALA-LC romanisation
+var arabicConsonants = [][3]string{
+	{"ح", "ḩ", "ḥ"},
+	{"ص", "ş", "ṣ"},
+	{"ض", "ḑ", "ḍ"},
+	{"ط", "ţ", "ṭ"},
+	{"ظ", "z̧", "ẓ"},
+}
+
+// arabicCommonConsonants are romanised the same in BGN/PCGN and ALA-LC
+var arabicCommonConsonants = []string{
+	"ب", "b", "ت", "t", "ث", "th", "ج", "j", "خ", "kh", "د", "d", "ذ", "dh", "ر", "r",
+	"ز", "z", "س", "s", "ش", "sh", "ع", "ʻ", "غ", "gh", "ف", "f", "ق", "q", "ك", "k",
+	"ل", "l", "م", "m", "ن", "n", "ه", "h", "و", "w", "ي", "y",
+}
+
+// arabicRules creates the Arabic rules, given the romanisation of the letters in arabicConsonants (index 1 for BGN/PCGN, 2 for ALA-LC). Vowel marks are romanised if present, and shadda doubles the consonant. Unvocalised text is romanised letter by letter, with alif as ā, and waw and ya as w and y.
+func arabicRules(column int) []Rule {
+	consonants := append([]string{}, arabicCommonConsonants...)
+	for _, c := range arabicConsonants {
+		consonants = append(consonants, c[0], c[column])
+	}
+	rules := []Rule{
+		{From: "ال", To: "al-", Preceding: "^"},
+		{From: "أ" + fatha, To: "a", Preceding: "^"},
+		{From: "أ" + damma, To: "u", Preceding: "^"},
+		{From: "إ" + kasra, To: "i", Preceding: "^"},
+		{From: "أ", To: "a", Preceding: "^"},
+		{From: "إ", To: "i", Preceding: "^"},
+		{From: "آ", To: "ā", Preceding: "^"},
+	}
+	rules = append(rules, pairs(
+		"ا", "ā", "آ", "ʼā", "أ", "ʼ", "إ", "ʼ", "ؤ", "ʼ", "ئ", "ʼ", "ء", "ʼ", "ى", "á", "ة", "h",
+		fatha+"ا", "ā", fatha+"ى", "á", damma+"و", "ū", kasra+"ي", "ī",
+		fatha, "a", damma, "u", kasra, "i", fathatan, "an", dammatan, "un", kasratan, "in",
+		sukun, "", shadda, "", "ٰ", "ā", "ـ", "",
+		"،", ",", "؛", ";", "؟", "?",
+		"٠", "0", "١", "1", "٢", "2", "٣", "3", "٤", "4", "٥", "5", "٦", "6", "٧", "7", "٨", "8", "٩", "9",
+	)...)
+	vowels := [][2]string{{"", ""}, {fatha, "a"}, {damma, "u"}, {kasra, "i"}, {fathatan, "an"}, {dammatan, "un"}, {kasratan, "in"}}
+	for i := 0; i+1 < len(consonants); i += 2 {
+		c, latin := consonants[i], consonants[i+1]
+		rules = append(rules, Rule{From: c, To: latin})
+		// the vowel mark is placed before shadda by canonical ordering, so the combinations with vowels are listed explicitly, including long vowels
+		for _, v := range vowels {
+			rules = append(rules, Rule{From: c + shadda + v[0], To: latin + latin + v[1]})
+		}
+		rules = append(rules,
+			Rule{From: c + shadda + fatha + "ا", To: latin + latin + "ā"},
+			Rule{From: c + shadda + damma + "و", To: latin + latin + "ū"},
+			Rule{From: c + shadda + kasra + "ي", To: latin + latin + "ī"},
+		)
+	}
+	return rules
+}
+
+// Tables holds the built-in transliteration tables, sorted by name. The Russian tables cover the Russian alphabet only; iso9 covers the Cyrillic letters of the Slavic languages. Arabic romanisation is letter by letter: without vowel marks, short vowels are not added.
+var Tables = []Table{
+	{Name: "ala-lc-ar", Description: "Arabic to Latin, ALA-LC", Rules: arabicRules(2)},
+	{Name: "ala-lc-el", Description: "Greek to Latin, ALA-LC (modern Greek)", Rules: alaLCGreek},
+	{Name: "ala-lc-ru", Description: "Russian Cyrillic to Latin, ALA-LC", Rules: alaLCRussian},
+	{Name: "bgn-pcgn-ar", Description: "Arabic to Latin, BGN/PCGN 1956", Rules: arabicRules(1)},
+	{Name: "bgn-pcgn-el", Description: "Greek to Latin, BGN/PCGN 1996 (ELOT 743)", Rules: bgnPCGNGreek},
+	{Name: "bgn-pcgn-ru", Description: "Russian Cyrillic to Latin, BGN/PCGN 1947", Rules: bgnPCGNRussian},
+	{Name: "iso9", Description: "Cyrillic to Latin, ISO 9:1995 (reversible)", Rules: iso9},
+}
+
+// TableNames returns the names of the built-in tables
+func TableNames() []string {
+	res := []string{}
+	for _, t := range Tables {
+		res = append(res, t.Name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// LookupTable returns the built-in table with the given name
+func LookupTable(name string) (Table, error) {
+	for _, t := range Tables {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Table{}, fmt.Errorf("unknown transliteration table %s, expected one of: %s", name, strings.Join(TableNames(), ", "))
+}
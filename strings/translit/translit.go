@@ -0,0 +1,250 @@
+// Package translit is a table-driven transliterator, with longest-match rule application and simple contextual rules, and built-in romanisation tables for Cyrillic, Greek and Arabic script (see Tables).
+package translit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/HannaLindgren/go-utils/io"
+)
+
+// Rule is a transliteration rule, rewriting From into To. Rules are written in lower case, and are applied case insensitively: the case of the input is transferred to the output (Щука => Shchuka, ЩУКА => SHCHUKA).
+type Rule struct {
+	// From is the input string
+	From string
+	// To is the output string
+	To string
+	// Preceding is an optional context: if non-empty, the rule only applies after one of these characters; ^ stands for the start of a word. Combining marks are ignored in the context (е and ё are both е).
+	Preceding string
+	// Following is an optional context: if non-empty, the rule only applies before one of these characters; $ stands for the end of a word
+	Following string
+}
+
+func (r Rule) hasContext() bool {
+	return r.Preceding != "" || r.Following != ""
+}
+
+// compiledRule is a rule normalised for matching
+type compiledRule struct {
+	Rule
+	preceding map[rune]bool
+	following map[rune]bool
+	// index is the position of the rule in Transliterator.source
+	index int
+}
+
+// Transliterator applies a set of rules to strings. At each position of the input, the longest matching rule is applied; if several rules have the same input string, the first one with a matching context is used, and rules with a context are tried before rules without one. Characters not matched by any rule are kept as is.
+type Transliterator struct {
+	rules  map[string][]compiledRule
+	maxLen int
+	source []Rule
+}
+
+// lower lowercases and decomposes a string, which is the form used for matching
+func lower(s string) string {
+	return strings.Map(unicode.ToLower, norm.NFD.String(s))
+}
+
+// contextSet converts a context string into a set of lower case base characters
+func contextSet(s string) map[rune]bool {
+	if s == "" {
+		return nil
+	}
+	res := map[rune]bool{}
+	for _, r := range lower(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			res[r] = true
+		}
+	}
+	return res
+}
+
+// New creates a transliterator from a list of rules. A later rule replaces an earlier rule with the same input string and context, so that a table can be extended or modified by appending rules.
+func New(rules []Rule) (*Transliterator, error) {
+	t := &Transliterator{rules: map[string][]compiledRule{}}
+	for _, r := range rules {
+		if r.From == "" {
+			return nil, fmt.Errorf("empty input string in rule: %#v", r)
+		}
+		from := lower(r.From)
+		cr := compiledRule{Rule: r, preceding: contextSet(r.Preceding), following: contextSet(r.Following), index: len(t.source)}
+		replaced := false
+		for i, old := range t.rules[from] {
+			if old.Preceding == r.Preceding && old.Following == r.Following {
+				cr.index = old.index
+				t.rules[from][i] = cr
+				t.source[old.index] = r
+				replaced = true
+			}
+		}
+		if !replaced {
+			t.rules[from] = append(t.rules[from], cr)
+			t.source = append(t.source, r)
+		}
+		if n := len([]rune(from)); n > t.maxLen {
+			t.maxLen = n
+		}
+	}
+	for _, rs := range t.rules {
+		sort.SliceStable(rs, func(i, j int) bool { return rs[i].hasContext() && !rs[j].hasContext() })
+	}
+	return t, nil
+}
+
+// Rules returns the rules of the transliterator, in the order given to New (replaced rules are not included)
+func (t *Transliterator) Rules() []Rule {
+	return append([]Rule{}, t.source...)
+}
+
+// Inverse creates a transliterator for the reverse direction, by swapping the input and output of each rule. This is only possible for tables without contextual rules and deletions, where no two rules have the same output, such as ISO 9 (see Tables). Note that a round trip may still fail for ambiguous output sequences, unless the scheme is designed to be reversible.
+func (t *Transliterator) Inverse() (*Transliterator, error) {
+	seen := map[string]Rule{}
+	rules := []Rule{}
+	for _, r := range t.source {
+		if r.hasContext() {
+			return nil, fmt.Errorf("not reversible: contextual rule %s => %s", r.From, r.To)
+		}
+		if r.To == "" {
+			return nil, fmt.Errorf("not reversible: deletion rule %s => %s", r.From, r.To)
+		}
+		to := lower(r.To)
+		if old, ok := seen[to]; ok && lower(old.From) != lower(r.From) {
+			return nil, fmt.Errorf("not reversible: both %s and %s => %s", old.From, r.From, r.To)
+		}
+		seen[to] = r
+		rules = append(rules, Rule{From: r.To, To: r.From})
+	}
+	return New(rules)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+// base returns the first character at or after i (if step is 1), or at or before i (if step is -1), that is not a combining mark
+func base(rs []rune, i, step int) (rune, bool) {
+	for ; i >= 0 && i < len(rs); i += step {
+		if !unicode.Is(unicode.Mn, rs[i]) {
+			return rs[i], true
+		}
+	}
+	return 0, false
+}
+
+func contextMatches(set map[rune]bool, boundary rune, r rune, ok bool) bool {
+	if set == nil {
+		return true
+	}
+	if !ok || !isWordRune(r) {
+		return set[boundary]
+	}
+	return set[r]
+}
+
+// match returns the longest matching rule at position i
+func (t *Transliterator) match(rs []rune, i int) (compiledRule, int, bool) {
+	for n := min(t.maxLen, len(rs)-i); n > 0; n-- {
+		for _, cr := range t.rules[string(rs[i:i+n])] {
+			prev, prevOK := base(rs, i-1, -1)
+			next, nextOK := base(rs, i+n, 1)
+			if contextMatches(cr.preceding, '^', prev, prevOK) && contextMatches(cr.following, '$', next, nextOK) {
+				return cr, n, true
+			}
+		}
+	}
+	return compiledRule{}, 0, false
+}
+
+// isUpper returns true if the character at or after i (step 1), or at or before i (step -1), skipping combining marks, is an upper case letter
+func isUpper(rs []rune, i, step int) bool {
+	r, ok := base(rs, i, step)
+	return ok && unicode.IsUpper(r)
+}
+
+// applyCase transfers the case of the matched input to the output: all upper case if the input is all upper case and either has several letters or is next to an upper case letter, otherwise upper case initial if the input starts with an upper case letter
+func applyCase(in []rune, i, n int, to string) string {
+	nLetters, nUpper := 0, 0
+	for _, r := range in[i : i+n] {
+		if unicode.IsLetter(r) {
+			nLetters++
+			if unicode.IsUpper(r) {
+				nUpper++
+			}
+		}
+	}
+	first, _ := base(in, i, 1)
+	if nUpper == 0 || !unicode.IsUpper(first) {
+		return to
+	}
+	if nUpper == nLetters && (nLetters > 1 || isUpper(in, i+n, 1) || isUpper(in, i-1, -1)) {
+		return strings.ToUpper(to)
+	}
+	rs := []rune(to)
+	for j, r := range rs {
+		if unicode.IsLetter(r) {
+			rs[j] = unicode.ToTitle(r)
+			break
+		}
+	}
+	return string(rs)
+}
+
+// Transliterate applies the rules to the input string. The output is NFC normalised.
+func (t *Transliterator) Transliterate(s string) string {
+	in := []rune(norm.NFD.String(s))
+	lc := make([]rune, len(in))
+	for i, r := range in {
+		lc[i] = unicode.ToLower(r)
+	}
+	var res strings.Builder
+	for i := 0; i < len(in); {
+		cr, n, ok := t.match(lc, i)
+		if !ok {
+			res.WriteRune(in[i])
+			i++
+			continue
+		}
+		res.WriteString(applyCase(in, i, n, cr.To))
+		i += n
+	}
+	return norm.NFC.String(res.String())
+}
+
+// ParseRules parses transliteration rules, one rule per line, with two to four tab separated fields: input, output, preceding context and following context (see Rule). Empty lines and lines starting with # are ignored. The source name is used in error messages.
+func ParseRules(lines []string, source string) ([]Rule, error) {
+	res := []Rule{}
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		if len(fs) < 2 || len(fs) > 4 {
+			return nil, fmt.Errorf("%s:%d: expected 2 to 4 tab separated fields, found %d", source, i+1, len(fs))
+		}
+		if fs[0] == "" {
+			return nil, fmt.Errorf("%s:%d: empty input string", source, i+1)
+		}
+		r := Rule{From: fs[0], To: fs[1]}
+		if len(fs) > 2 {
+			r.Preceding = fs[2]
+		}
+		if len(fs) > 3 {
+			r.Following = fs[3]
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// ReadRules reads transliteration rules from a file (see ParseRules for the file format)
+func ReadRules(fName string) ([]Rule, error) {
+	lines, err := io.ReadFileToLines(fName)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(lines, fName)
+}
@@ -0,0 +1,145 @@
+package translit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var fsExpGot = "expected: %#v ; got: %#v"
+
+func TestTables(t *testing.T) {
+	var test = func(table, in, exp string) {
+		tbl, err := LookupTable(table)
+		if err != nil {
+			t.Fatalf("Got error from LookupTable: %v", err)
+		}
+		tr, err := tbl.New()
+		if err != nil {
+			t.Fatalf("Got error from New: %v", err)
+		}
+		if got := tr.Transliterate(in); got != exp {
+			t.Errorf("%s: "+fsExpGot, table, exp, got)
+		}
+	}
+
+	test("iso9", "Щукин", "Ŝukin")
+	test("iso9", "Київ", "Kiïv")
+	test("iso9", "Београд", "Beograd")
+	test("iso9", "ЖЁЛТЫЙ дом", "ŽËLTYJ dom")
+
+	test("bgn-pcgn-ru", "Ельцин", "Yelʹtsin")
+	test("bgn-pcgn-ru", "Щукин", "Shchukin")
+	test("bgn-pcgn-ru", "ЩУКИН", "SHCHUKIN")
+	test("bgn-pcgn-ru", "Юрий Гагарин", "Yuriy Gagarin")
+	test("bgn-pcgn-ru", "подъезд", "podʺyezd")
+	test("bgn-pcgn-ru", "Моё", "Moyë")
+
+	test("ala-lc-ru", "Ельцин", "Elʹt͡sin")
+	test("ala-lc-ru", "Юрий", "I͡uriĭ")
+	test("ala-lc-ru", "ЭХО", "ĖKHO")
+
+	test("bgn-pcgn-el", "Αθήνα", "Athína")
+	test("bgn-pcgn-el", "ΑΘΗΝΑ", "ATHINA")
+	test("bgn-pcgn-el", "Ευρώπη", "Evrópi")
+	test("bgn-pcgn-el", "αυτός", "aftós")
+	test("bgn-pcgn-el", "Μπαλάφας", "Baláfas")
+	test("bgn-pcgn-el", "λάμπα", "lámba")
+	test("bgn-pcgn-el", "Πειραιάς", "Peiraiás")
+
+	test("ala-lc-el", "Αθήνα", "Athēna")
+	test("ala-lc-el", "Ευρώπη", "Eurōpē")
+	test("ala-lc-el", "ῥήτωρ", "rhētōr")
+
+	test("bgn-pcgn-ar", "محمد", "mḩmd")
+	test("ala-lc-ar", "مُحَمَّد", "muḥammad")
+	test("ala-lc-ar", "الكِتاب", "al-kitāb")
+	test("bgn-pcgn-ar", "بَيْرُوت", "bayrūt")
+	test("ala-lc-ar", "١٩٤٨", "1948")
+
+	// characters without rules are kept as is
+	test("iso9", "Москва 2024!", "Moskva 2024!")
+
+	if _, err := LookupTable("no-such-table"); err == nil {
+		t.Errorf("Expected error for unknown table")
+	}
+}
+
+func TestInverse(t *testing.T) {
+	tbl, _ := LookupTable("iso9")
+	tr, err := tbl.New()
+	if err != nil {
+		t.Fatalf("Got error from New: %v", err)
+	}
+	inv, err := tr.Inverse()
+	if err != nil {
+		t.Fatalf("Got error from Inverse: %v", err)
+	}
+	for _, s := range []string{"Щукин", "Київ", "ЖЁЛТЫЙ дом", "Ђорђе Љубић", "объём"} {
+		lat := tr.Transliterate(s)
+		if got := inv.Transliterate(lat); got != s {
+			t.Errorf("round trip via %s "+fsExpGot, lat, s, got)
+		}
+	}
+
+	for _, name := range []string{"bgn-pcgn-ru", "ala-lc-el"} {
+		tbl, _ := LookupTable(name)
+		tr, _ := tbl.New()
+		if _, err := tr.Inverse(); err == nil {
+			t.Errorf("Expected error from Inverse for %s", name)
+		}
+	}
+}
+
+func TestContextAndOverride(t *testing.T) {
+	tr, err := New([]Rule{
+		{From: "c", To: "k"},
+		{From: "c", To: "s", Following: "eiy"},
+		{From: "ch", To: "tsch"},
+		{From: "x", To: "ks", Preceding: "^"},
+		{From: "c", To: "c", Following: "$"},
+		{From: "ch", To: "tj"},
+	})
+	if err != nil {
+		t.Fatalf("Got error from New: %v", err)
+	}
+	var test = func(in, exp string) {
+		if got := tr.Transliterate(in); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("cactus", "kaktus")
+	test("Cicero", "Sisero")
+	test("Chic", "Tjic")
+	test("xerox", "kserox")
+	if got, exp := len(tr.Rules()), 5; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestReadRules(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "rules.tsv")
+	data := "# test rules\nш\tsch\nе\tje\t^\n\nе\te\nщ\tschtsch\tx\ty\tz\n"
+	if err := os.WriteFile(fName, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadRules(fName); err == nil {
+		t.Errorf("Expected error for five fields")
+	}
+	data = "# test rules\nш\tsch\nе\tje\t^\n\nе\te\nщ\tschtsch\n"
+	if err := os.WriteFile(fName, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := ReadRules(fName)
+	if err != nil {
+		t.Fatalf("Got error from ReadRules: %v", err)
+	}
+	tr, err := New(rules)
+	if err != nil {
+		t.Fatalf("Got error from New: %v", err)
+	}
+	exp := "Jeschtsche"
+	if got := tr.Transliterate("Еще"); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
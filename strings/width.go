@@ -0,0 +1,248 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wideTable holds the characters with East Asian Width Wide (W) or Fullwidth (F), including emoji with default emoji presentation, plus the regional indicators (so that a flag is two columns wide)
+var wideTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115F, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232A, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23EC, Stride: 1},
+		{Lo: 0x23F0, Hi: 0x23F0, Stride: 1},
+		{Lo: 0x23F3, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x25FD, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267F, Hi: 0x267F, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26A1, Hi: 0x26A1, Stride: 1},
+		{Lo: 0x26AA, Hi: 0x26AB, Stride: 1},
+		{Lo: 0x26BD, Hi: 0x26BE, Stride: 1},
+		{Lo: 0x26C4, Hi: 0x26C5, Stride: 1},
+		{Lo: 0x26CE, Hi: 0x26CE, Stride: 1},
+		{Lo: 0x26D4, Hi: 0x26D4, Stride: 1},
+		{Lo: 0x26EA, Hi: 0x26EA, Stride: 1},
+		{Lo: 0x26F2, Hi: 0x26F3, Stride: 1},
+		{Lo: 0x26F5, Hi: 0x26F5, Stride: 1},
+		{Lo: 0x26FA, Hi: 0x26FA, Stride: 1},
+		{Lo: 0x26FD, Hi: 0x26FD, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270A, Hi: 0x270B, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x2E80, Hi: 0x2E99, Stride: 1},
+		{Lo: 0x2E9B, Hi: 0x2EF3, Stride: 1},
+		{Lo: 0x2F00, Hi: 0x2FD5, Stride: 1},
+		{Lo: 0x2FF0, Hi: 0x2FFF, Stride: 1},
+		{Lo: 0x3000, Hi: 0x303E, Stride: 1},
+		{Lo: 0x3041, Hi: 0x3096, Stride: 1},
+		{Lo: 0x3099, Hi: 0x30FF, Stride: 1},
+		{Lo: 0x3105, Hi: 0x312F, Stride: 1},
+		{Lo: 0x3131, Hi: 0x318E, Stride: 1},
+		{Lo: 0x3190, Hi: 0x31E5, Stride: 1},
+		{Lo: 0x31EF, Hi: 0x321E, Stride: 1},
+		{Lo: 0x3220, Hi: 0x3247, Stride: 1},
+		{Lo: 0x3250, Hi: 0xA48C, Stride: 1},
+		{Lo: 0xA490, Hi: 0xA4C6, Stride: 1},
+		{Lo: 0xA960, Hi: 0xA97C, Stride: 1},
+		{Lo: 0xAC00, Hi: 0xD7A3, Stride: 1},
+		{Lo: 0xF900, Hi: 0xFAFF, Stride: 1},
+		{Lo: 0xFE10, Hi: 0xFE19, Stride: 1},
+		{Lo: 0xFE30, Hi: 0xFE52, Stride: 1},
+		{Lo: 0xFE54, Hi: 0xFE66, Stride: 1},
+		{Lo: 0xFE68, Hi: 0xFE6B, Stride: 1},
+		{Lo: 0xFF01, Hi: 0xFF60, Stride: 1},
+		{Lo: 0xFFE0, Hi: 0xFFE6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16FE0, Hi: 0x16FE4, Stride: 1},
+		{Lo: 0x16FF0, Hi: 0x16FF1, Stride: 1},
+		{Lo: 0x17000, Hi: 0x187F7, Stride: 1},
+		{Lo: 0x18800, Hi: 0x18CD5, Stride: 1},
+		{Lo: 0x18D00, Hi: 0x18D08, Stride: 1},
+		{Lo: 0x1AFF0, Hi: 0x1B2FB, Stride: 1},
+		{Lo: 0x1F004, Hi: 0x1F004, Stride: 1},
+		{Lo: 0x1F0CF, Hi: 0x1F0CF, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F1E6, Hi: 0x1F202, Stride: 1},
+		{Lo: 0x1F210, Hi: 0x1F23B, Stride: 1},
+		{Lo: 0x1F240, Hi: 0x1F248, Stride: 1},
+		{Lo: 0x1F250, Hi: 0x1F251, Stride: 1},
+		{Lo: 0x1F260, Hi: 0x1F265, Stride: 1},
+		{Lo: 0x1F300, Hi: 0x1F320, Stride: 1},
+		{Lo: 0x1F32D, Hi: 0x1F335, Stride: 1},
+		{Lo: 0x1F337, Hi: 0x1F37C, Stride: 1},
+		{Lo: 0x1F37E, Hi: 0x1F393, Stride: 1},
+		{Lo: 0x1F3A0, Hi: 0x1F3CA, Stride: 1},
+		{Lo: 0x1F3CF, Hi: 0x1F3D3, Stride: 1},
+		{Lo: 0x1F3E0, Hi: 0x1F3F0, Stride: 1},
+		{Lo: 0x1F3F4, Hi: 0x1F3F4, Stride: 1},
+		{Lo: 0x1F3F8, Hi: 0x1F43E, Stride: 1},
+		{Lo: 0x1F440, Hi: 0x1F440, Stride: 1},
+		{Lo: 0x1F442, Hi: 0x1F4FC, Stride: 1},
+		{Lo: 0x1F4FF, Hi: 0x1F53D, Stride: 1},
+		{Lo: 0x1F54B, Hi: 0x1F54E, Stride: 1},
+		{Lo: 0x1F550, Hi: 0x1F567, Stride: 1},
+		{Lo: 0x1F57A, Hi: 0x1F57A, Stride: 1},
+		{Lo: 0x1F595, Hi: 0x1F596, Stride: 1},
+		{Lo: 0x1F5A4, Hi: 0x1F5A4, Stride: 1},
+		{Lo: 0x1F5FB, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6C5, Stride: 1},
+		{Lo: 0x1F6CC, Hi: 0x1F6CC, Stride: 1},
+		{Lo: 0x1F6D0, Hi: 0x1F6D2, Stride: 1},
+		{Lo: 0x1F6D5, Hi: 0x1F6D7, Stride: 1},
+		{Lo: 0x1F6DC, Hi: 0x1F6DF, Stride: 1},
+		{Lo: 0x1F6EB, Hi: 0x1F6EC, Stride: 1},
+		{Lo: 0x1F6F4, Hi: 0x1F6FC, Stride: 1},
+		{Lo: 0x1F7E0, Hi: 0x1F7EB, Stride: 1},
+		{Lo: 0x1F7F0, Hi: 0x1F7F0, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F93A, Stride: 1},
+		{Lo: 0x1F93C, Hi: 0x1F945, Stride: 1},
+		{Lo: 0x1F947, Hi: 0x1F9FF, Stride: 1},
+		{Lo: 0x1FA70, Hi: 0x1FA7C, Stride: 1},
+		{Lo: 0x1FA80, Hi: 0x1FA89, Stride: 1},
+		{Lo: 0x1FA8F, Hi: 0x1FAC6, Stride: 1},
+		{Lo: 0x1FACE, Hi: 0x1FADC, Stride: 1},
+		{Lo: 0x1FADF, Hi: 0x1FAE9, Stride: 1},
+		{Lo: 0x1FAF0, Hi: 0x1FAF8, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2FFFD, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3FFFD, Stride: 1},
+	},
+}
+
+// RuneWidth returns the number of terminal columns used by a rune: 0 for combining marks, format characters (such as zero width space and joiner), control characters and Hangul medial vowels and final consonants; 2 for East Asian wide and fullwidth characters and emoji; and 1 otherwise. Characters with ambiguous East Asian width are treated as narrow.
+func RuneWidth(r rune) int {
+	switch {
+	case r >= 0x1160 && r <= 0x11FF, r >= 0xD7B0 && r <= 0xD7FF:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc):
+		return 0
+	case unicode.Is(wideTable, r):
+		return 2
+	}
+	return 1
+}
+
+// graphemeWidth returns the number of terminal columns used by a grapheme cluster: the widest rune of the cluster, where an emoji presentation selector (U+FE0F) makes the cluster wide
+func graphemeWidth(g string) int {
+	w := 0
+	for _, r := range g {
+		if r == 0xFE0F {
+			return 2
+		}
+		w = max(w, RuneWidth(r))
+	}
+	return w
+}
+
+// Width returns the number of terminal columns used by the input string (display width), computed per grapheme cluster (see Graphemes and RuneWidth), so that e.g. combining marks, emoji ZWJ sequences and flags are counted correctly
+func Width(s string) int {
+	w := 0
+	for s != "" {
+		var g string
+		g, s = FirstGrapheme(s)
+		w += graphemeWidth(g)
+	}
+	return w
+}
+
+// PadRight pads the input string with spaces to the right, up to the given display width (see Width). Strings that are already as wide or wider are returned unchanged.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-Width(s)))
+}
+
+// PadLeft pads the input string with spaces to the left, up to the given display width (see Width). Strings that are already as wide or wider are returned unchanged.
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", max(0, width-Width(s))) + s
+}
+
+// PadCenter pads the input string with spaces on both sides, up to the given display width (see Width); if the padding is uneven, the extra space is added to the right. Strings that are already as wide or wider are returned unchanged.
+func PadCenter(s string, width int) string {
+	pad := max(0, width-Width(s))
+	return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+}
+
+// truncateToWidth returns the longest prefix of the input string, in whole grapheme clusters, that is at most the given display width, and its width
+func truncateToWidth(s string, width int) (string, int) {
+	pos, w := 0, 0
+	for pos < len(s) {
+		g, _ := FirstGrapheme(s[pos:])
+		gw := graphemeWidth(g)
+		if w+gw > width {
+			break
+		}
+		pos += len(g)
+		w += gw
+	}
+	return s[:pos], w
+}
+
+// TruncateWidth truncates the input string to at most the given display width (see Width), without breaking grapheme clusters. If the string is truncated, the ellipsis (e.g. "…" or "...") is appended, and included in the width. If the ellipsis itself is too wide, the string is truncated without it.
+func TruncateWidth(s string, width int, ellipsis string) string {
+	if Width(s) <= width {
+		return s
+	}
+	ew := Width(ellipsis)
+	if ew > width {
+		res, _ := truncateToWidth(s, width)
+		return res
+	}
+	res, _ := truncateToWidth(s, width-ew)
+	return res + ellipsis
+}
+
+// WrapWidth wraps the input string into lines of at most the given display width (see Width), breaking lines at white space. White space between words is collapsed into a single space, and line breaks in the input are kept. Words that are wider than the line, such as CJK text without spaces, are broken between grapheme clusters.
+func WrapWidth(s string, width int) []string {
+	width = max(1, width)
+	res := []string{}
+	for _, para := range strings.Split(s, "\n") {
+		n := len(res)
+		line, lineWidth := "", 0
+		flush := func() {
+			res = append(res, line)
+			line, lineWidth = "", 0
+		}
+		for _, word := range strings.Fields(para) {
+			ww := Width(word)
+			switch {
+			case line != "" && lineWidth+1+ww <= width:
+				line += " " + word
+				lineWidth += 1 + ww
+				continue
+			case line != "":
+				flush()
+			}
+			for ww > width {
+				part, pw := truncateToWidth(word, width)
+				if part == "" {
+					// a single grapheme cluster wider than the line
+					part, _ = FirstGrapheme(word)
+					pw = graphemeWidth(part)
+				}
+				line, lineWidth = part, pw
+				flush()
+				word = word[len(part):]
+				ww -= pw
+			}
+			line, lineWidth = word, ww
+		}
+		if line != "" || len(res) == n {
+			flush()
+		}
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+package strings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	var test = func(in string, exp int) {
+		if got := Width(in); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("", 0)
+	test("abc", 3)
+	test("Åsa", 3)
+	test("A\u030asa", 3)
+	test("日本語", 6)
+	test("ｶﾀｶﾅ", 4)
+	test("ＡＢＣ", 6)
+	test("한국어", 6)
+	test("한", 2)
+	test("😀", 2)
+	test("👍🏽", 2)
+	test("👩‍👩‍👧", 2)
+	test("🇸🇪", 2)
+	test("❤️", 2)
+	test("❤", 1)
+	test("a\u200bb", 2)
+	test("a\tb", 2)
+	test("×", 1)
+}
+
+func TestPad(t *testing.T) {
+	var test = func(got, exp string) {
+		if got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test(PadRight("日本", 6), "日本  ")
+	test(PadRight("Å", 3), "Å  ")
+	test(PadRight("abcdef", 3), "abcdef")
+	test(PadLeft("日本", 6), "  日本")
+	test(PadLeft("😀", 3), " 😀")
+	test(PadCenter("日", 5), " 日  ")
+	test(PadCenter("ab", 6), "  ab  ")
+}
+
+func TestTruncateWidth(t *testing.T) {
+	var test = func(in string, width int, ellipsis string, exp string) {
+		if got := TruncateWidth(in, width, ellipsis); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("abcdef", 6, "…", "abcdef")
+	test("abcdef", 5, "…", "abcd…")
+	test("abcdef", 5, "...", "ab...")
+	test("日本語テキスト", 7, "…", "日本語…")
+	test("日本語テキスト", 8, "…", "日本語…")
+	test("ééé", 2, "", "éé")
+	test("👩‍👩‍👧 family", 3, "…", "👩‍👩‍👧…")
+	test("abcdef", 2, "...", "ab")
+	test("abcdef", 0, "…", "")
+}
+
+func TestWrapWidth(t *testing.T) {
+	var test = func(in string, width int, exp []string) {
+		got := WrapWidth(in, width)
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("", 10, []string{""})
+	test("the quick brown fox", 10, []string{"the quick", "brown fox"})
+	test("the  quick\tbrown   fox", 9, []string{"the quick", "brown fox"})
+	test("the quick brown fox", 100, []string{"the quick brown fox"})
+	test("abcdefghij klm", 4, []string{"abcd", "efgh", "ij", "klm"})
+	test("日本語のテキスト", 5, []string{"日本", "語の", "テキ", "スト"})
+	test("första stycket\n\nandra", 8, []string{"första", "stycket", "", "andra"})
+	test("a 日本", 1, []string{"a", "日", "本"})
+}
+
+func TestWideTableSorted(t *testing.T) {
+	for i := 1; i < len(wideTable.R16); i++ {
+		if wideTable.R16[i].Lo <= wideTable.R16[i-1].Hi {
+			t.Errorf("R16 range %d overlaps or is out of order", i)
+		}
+	}
+	for i := 1; i < len(wideTable.R32); i++ {
+		if wideTable.R32[i].Lo <= wideTable.R32[i-1].Hi {
+			t.Errorf("R32 range %d overlaps or is out of order", i)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package capitalize
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/io"
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Convert each line to title case, keeping stopwords in lower case (e.g. The Lord of the Rings, McDonald, d'Artagnan)"
+
+var caser str.TitleCaser
+
+func convert(s string) (string, error) {
+	res := caser.Title(s)
+	if str.ToLower(s, caser.Lang) != str.ToLower(res, caser.Lang) {
+		return "", fmt.Errorf("expected output string to equal input string except for case, but found: <%s> => <%s>", s, res)
+	}
+	return res, nil
+}
+
+// Main runs the capitalize command, using the command line arguments in os.Args
+func Main() {
+	downcaseRemainder := flag.Bool("d", false, "downcase remainder")
+	stopwordFile := flag.String("s", "", "Read stopwords (words kept in lower case) from `file`, one word per line, instead of using the built-in list for the language")
+	noAcronyms := flag.Bool("A", false, "Don't preserve all upper case words (acronyms) (default false)")
+	r := lib.NewRunner(Description)
+	r.LanguageFlag()
+	r.Parse()
+
+	caser = str.NewTitleCaser(r.Language())
+	caser.DowncaseRemainder = *downcaseRemainder
+	caser.PreserveAcronyms = !*noAcronyms
+	if *stopwordFile != "" {
+		lines, err := io.ReadFileToLines(*stopwordFile)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		caser.Stopwords = map[string]bool{}
+		for _, l := range lines {
+			if w := strings.TrimSpace(l); w != "" {
+				caser.Stopwords[str.ToLower(w, caser.Lang)] = true
+			}
+		}
+	}
+
+	err := r.RunConverterE(convert)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
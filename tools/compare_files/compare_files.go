@@ -0,0 +1,342 @@
+package comparefiles
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	hio "github.com/HannaLindgren/go-utils/io"
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Compare the lines of two files, with statistics on common and unique lines"
+
+type output int
+
+const (
+	f1 output = iota
+	f2
+	all
+	both
+	diff
+	stats
+)
+
+var modes = []output{f1, f2, all, both, diff, stats}
+
+//var modesString string = strings.Join(strings.Fields(fmt.Sprint(modes)), "|")
+
+func modesHelp(prefix string) string {
+	return strings.Join([]string{
+		prefix + f1.String() + ":    Lines in file1 only",
+		prefix + f2.String() + ":    Lines in file2 only",
+		prefix + all.String() + ":   All lines (with diff info)",
+		prefix + both.String() + ":  Lines occurring in both files",
+		prefix + diff.String() + ":  Mismatching lines",
+		prefix + stats.String() + ": Statistics",
+	}, "\n")
+}
+
+const _outputName = "f1f2allbothdiffstats"
+
+var _outputIndex = [...]uint8{0, 2, 4, 7, 11, 15, 20}
+
+func string2output(s string) output {
+	switch s {
+	case "f1":
+		return f1
+	case "f2":
+		return f2
+	case "all":
+		return all
+	case "both":
+		return both
+	case "diff":
+		return diff
+	case "stats":
+		return stats
+	}
+	log.Fatalf("Invalid output mode: %s", s)
+	return stats
+}
+func (i output) String() string {
+	if i < 0 || i >= output(len(_outputIndex)-1) {
+		return fmt.Sprintf("output(%d)", i)
+	}
+	return _outputName[_outputIndex[i]:_outputIndex[i+1]]
+}
+
+func max(n1, n2 int) int {
+	if n1 > n2 {
+		return n1
+	}
+	return n2
+}
+
+func readLines(fsys fs.FS, file string) ([]string, error) {
+	s, err := hio.ReadFileToStringFS(fsys, file)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file %s : %v", file, err)
+	}
+	var finalNewline = regexp.MustCompile("\n$")
+	s = finalNewline.ReplaceAllString(s, "")
+	return strings.Split(s, "\n"), nil
+}
+
+func readLine(lines []string, lineNo int) (string, error) {
+	if lineNo < len(lines) {
+		return lines[lineNo], nil
+	}
+	return "", fmt.Errorf("line %d is after EOF", lineNo)
+}
+
+// comparer holds the options and the statistics for a file comparison
+type comparer struct {
+	fsys fs.FS
+	out  io.Writer
+
+	ignoreCase   bool
+	keepOrdering bool
+	trim         bool
+	mode         output
+	// key is an optional key function, such as a phonetic key: lines with the same key are treated as equal
+	key func(string) string
+
+	f1Notf2  int
+	f2Notf1  int
+	nDiff    int
+	nBoth    int
+	nLines1  int
+	nLines2  int
+	sizeDiff int
+}
+
+var defaultMode = stats
+
+func newComparer(fsys fs.FS, out io.Writer) *comparer {
+	return &comparer{fsys: fsys, out: out, mode: defaultMode}
+}
+
+// norm returns the form of a line used for comparison (lower case if ignoring case, and the key, if there is a key function)
+func (c *comparer) norm(s string) string {
+	if c.ignoreCase {
+		s = strings.ToLower(s)
+	}
+	if c.key != nil {
+		s = c.key(s)
+	}
+	return s
+}
+
+func (c *comparer) equal(s1, s2 string) bool {
+	if c.key != nil {
+		return c.norm(s1) == c.norm(s2)
+	}
+	if c.ignoreCase {
+		return strings.EqualFold(s1, s2)
+	}
+	return s1 == s2
+}
+
+func (c *comparer) unsorted(lines1, lines2 []string) {
+	c.nLines1, c.nLines2 = len(lines1), len(lines2)
+	c.sizeDiff = int(math.Abs(float64(c.nLines2 - c.nLines1)))
+	lines := make(map[string][]string)
+	found := make(map[string]bool)
+	for _, l0 := range lines1 {
+		l := c.norm(l0)
+		lines[l] = append(lines[l], l0)
+	}
+	for _, l0 := range lines2 {
+		l := c.norm(l0)
+		inputs, exists := lines[l]
+		if exists {
+			c.nBoth++
+			for _, input := range inputs {
+				found[input] = true
+			}
+			if c.mode == both {
+				fmt.Fprintln(c.out, l0)
+			} else if c.mode == all {
+				fmt.Fprintf(c.out, "f1 & f2\t%s\n", l0)
+			}
+		} else {
+			c.f2Notf1++
+			c.nDiff++
+			if c.mode == f2 {
+				fmt.Fprintln(c.out, l0)
+			} else if c.mode == all || c.mode == diff {
+				fmt.Fprintf(c.out, "f2 not f1\t%s\n", l0)
+			}
+		}
+	}
+	for _, inputs := range lines {
+		for _, input := range inputs {
+			if _, ok := found[input]; !ok {
+				c.f1Notf2++
+				if c.mode == f1 {
+					fmt.Fprintln(c.out, input)
+				} else if c.mode == all || c.mode == diff {
+					fmt.Fprintf(c.out, "f1 not f2\t%s\n", input)
+				}
+			}
+		}
+	}
+}
+
+func (c *comparer) lineByLine(lines1, lines2 []string) {
+	c.nLines1, c.nLines2 = len(lines1), len(lines2)
+	max := max(c.nLines1, c.nLines2)
+
+	for i := 0; i < max; i++ {
+		l1, eof1 := readLine(lines1, i)
+		l2, eof2 := readLine(lines2, i)
+		if eof1 != nil && eof2 == nil {
+			c.nDiff++
+			c.sizeDiff++
+			if c.mode == all || c.mode == diff || c.mode == f1 {
+				fmt.Fprintf(c.out, "f2 after f1\tL%d\t%s\n", i, l2)
+			}
+		} else if eof1 == nil && eof2 != nil {
+			c.nDiff++
+			c.sizeDiff++
+			if c.mode == all || c.mode == diff || c.mode == f2 {
+				fmt.Fprintf(c.out, "f1 after f2\tL%d\t%s\n", i, l1)
+			}
+		} else if c.equal(l1, l2) {
+			c.nBoth++
+			if c.mode == both {
+				fmt.Fprintln(c.out, l1)
+			} else if c.mode == all {
+				fmt.Fprintf(c.out, "f1 & f2\tL%d\t%s\n", i, l2)
+			}
+		} else {
+			c.f1Notf2++
+			c.f2Notf1++
+			c.nDiff++
+			if c.mode == f1 {
+				fmt.Fprintln(c.out, l1)
+			}
+			if c.mode == f2 {
+				fmt.Fprintln(c.out, l2)
+			}
+			if c.mode == all || c.mode == diff {
+				fmt.Fprintf(c.out, "f1 not f2\tL%d\t%s\n", i, l1)
+				fmt.Fprintf(c.out, "f2 not f1\tL%d\t%s\n", i, l2)
+			}
+		}
+	}
+
+}
+
+// compare reads and compares the two files, and prints the result according to the output mode
+func (c *comparer) compare(file1, file2 string) error {
+	lines1, err := readLines(c.fsys, file1)
+	if err != nil {
+		return err
+	}
+	lines2, err := readLines(c.fsys, file2)
+	if err != nil {
+		return err
+	}
+
+	if c.keepOrdering {
+		c.lineByLine(lines1, lines2)
+	} else {
+		c.unsorted(lines1, lines2)
+	}
+
+	if c.mode == stats {
+		fmt.Fprintf(c.out, "F1 LINES READ:  %8d lines\n", c.nLines1)
+		fmt.Fprintf(c.out, "F2 LINES READ:  %8d lines\n", c.nLines2)
+		fmt.Fprintf(c.out, "FILE SIZE DIFF: %8d lines\n", c.sizeDiff)
+		fmt.Fprintf(c.out, "F1 not F2       %8d lines\n", c.f1Notf2)
+		fmt.Fprintf(c.out, "F2 not F1       %8d lines\n", c.f2Notf1)
+		fmt.Fprintf(c.out, "F1  &  F2       %8d lines\n", c.nBoth)
+		fmt.Fprintf(c.out, "TOTAL DIFF      %8d lines\n", c.nDiff)
+	}
+	return nil
+}
+
+func internalInitTests() {
+	for _, o := range modes {
+		s := o.String()
+		o2 := string2output(s)
+		if o2 != o {
+			log.Fatalf("Internal init error for output type: %s <=> %s", o, o2)
+		}
+	}
+}
+
+// Main runs the compare_files command, using the command line arguments in os.Args
+func Main() {
+
+	cmdname := filepath.Base(os.Args[0])
+
+	internalInitTests()
+
+	c := newComparer(hio.OSFS{}, os.Stdout)
+	flag.BoolVar(&c.ignoreCase, "i", false, "ignore case (default false)")
+	flag.BoolVar(&c.keepOrdering, "o", false, "keep line ordering (default false)")
+	flag.BoolVar(&c.trim, "t", false, "trim lines (default false)")
+	var modeF = flag.String("m", "", fmt.Sprintf("output mode (default %s)\n%s\n         ", defaultMode, modesHelp("          ")))
+	var keyF = flag.String("key", "", fmt.Sprintf("compare lines by phonetic key instead of exact text: %s", strings.Join(str.PhoneticKeyNames(), ", ")))
+
+	var printUsage = func() {
+		fmt.Fprintln(os.Stderr, cmdname+" <flags> <file1> <file2>")
+		fmt.Fprintln(os.Stderr, "\nOptional flags:")
+		flag.PrintDefaults()
+	}
+
+	flag.Usage = func() {
+		printUsage()
+		os.Exit(0)
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		printUsage()
+		os.Exit(0)
+	}
+
+	file1, file2 := flag.Arg(0), flag.Arg(1)
+	if file1 == file2 {
+		fmt.Printf("[%s] Comparing a file to itself doesn't make sense: %s\n", cmdname, file1)
+		return
+	}
+
+	if *modeF != "" {
+		c.mode = string2output(*modeF)
+	}
+	if *keyF != "" {
+		key, err := str.PhoneticKey(*keyF)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		c.key = key
+	}
+
+	fmt.Fprintf(os.Stderr, "File1: %s\n", file1)
+	fmt.Fprintf(os.Stderr, "File2: %s\n", file2)
+
+	fmt.Fprintf(os.Stderr, "IgnoreCase:   %v\n", c.ignoreCase)
+	fmt.Fprintf(os.Stderr, "KeepOrdering: %v\n", c.keepOrdering)
+	fmt.Fprintf(os.Stderr, "TrimSpace:    %v\n", c.trim)
+	fmt.Fprintf(os.Stderr, "Mode:         %s\n", c.mode.String())
+	if *keyF != "" {
+		fmt.Fprintf(os.Stderr, "Key:          %s\n", *keyF)
+	}
+
+	if err := c.compare(file1, file2); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package comparefiles
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+var fsExpGot = "expected: %#v ; got: %#v"
+
+var testFS = fstest.MapFS{
+	"file1.txt": {Data: []byte("a\nb\nc\n")},
+	"file2.txt": {Data: []byte("a\nB\nd\ne\n")},
+}
+
+func TestCompareUnsorted(t *testing.T) {
+	out := &bytes.Buffer{}
+	c := newComparer(testFS, out)
+	c.ignoreCase = true
+	c.mode = both
+	if err := c.compare("file1.txt", "file2.txt"); err != nil {
+		t.Fatalf("Got error from compare: %v", err)
+	}
+	exp := "a\nB\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if c.nBoth != 2 || c.f1Notf2 != 1 || c.f2Notf1 != 2 || c.sizeDiff != 1 {
+		t.Errorf(fsExpGot, []int{2, 1, 2, 1}, []int{c.nBoth, c.f1Notf2, c.f2Notf1, c.sizeDiff})
+	}
+}
+
+func TestCompareLineByLine(t *testing.T) {
+	out := &bytes.Buffer{}
+	c := newComparer(testFS, out)
+	c.keepOrdering = true
+	c.mode = diff
+	if err := c.compare("file1.txt", "file2.txt"); err != nil {
+		t.Fatalf("Got error from compare: %v", err)
+	}
+	exp := "f1 not f2\tL1\tb\nf2 not f1\tL1\tB\nf1 not f2\tL2\tc\nf2 not f1\tL2\td\nf2 after f1\tL3\te\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestCompareMissingFile(t *testing.T) {
+	c := newComparer(testFS, &bytes.Buffer{})
+	if err := c.compare("file1.txt", "nonexisting.txt"); err == nil {
+		t.Errorf("Expected error for non-existing file")
+	}
+}
+
+func TestComparePhoneticKey(t *testing.T) {
+	fsys := fstest.MapFS{
+		"names1.txt": {Data: []byte("Carl\nÅsa\nKjell\n")},
+		"names2.txt": {Data: []byte("Asa\nKarl\nBengt\n")},
+	}
+	out := &bytes.Buffer{}
+	c := newComparer(fsys, out)
+	c.key = str.NordicKey
+	c.mode = both
+	if err := c.compare("names1.txt", "names2.txt"); err != nil {
+		t.Fatalf("Got error from compare: %v", err)
+	}
+	exp := "Asa\nKarl\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if c.nBoth != 2 || c.f1Notf2 != 1 || c.f2Notf1 != 1 {
+		t.Errorf(fsExpGot, []int{2, 1, 1}, []int{c.nBoth, c.f1Notf2, c.f2Notf1})
+	}
+
+	out.Reset()
+	c = newComparer(fsys, out)
+	c.key = str.NordicKey
+	c.keepOrdering = true
+	c.mode = both
+	if err := c.compare("names1.txt", "names2.txt"); err != nil {
+		t.Fatalf("Got error from compare: %v", err)
+	}
+	if got := out.String(); got != "" {
+		t.Errorf(fsExpGot, "", got)
+	}
+}
@@ -0,0 +1,25 @@
+package downcase
+
+import (
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Convert each input line to lower case"
+
+// Main runs the downcase command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.LanguageFlag()
+	r.Parse()
+	lang := r.Language()
+	err := r.RunConverter(func(s string) string {
+		return strings.ToLower(s, lang)
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package findunicode
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script to search for unicode characters"
+
+var char string
+
+func process(s string) string {
+	var res = false
+	if strings.Contains(s, char) {
+		res = true
+	}
+	return fmt.Sprintf("%s\t%v", s, res)
+}
+
+func code2char(s string) (string, error) {
+	i, err := strconv.ParseInt(s, 16, 32)
+	if err != nil {
+		return "", err
+	}
+	r := rune(i)
+	return string(r), nil
+}
+
+// Main runs the find_unicode command, using the command line arguments in os.Args
+func Main() {
+	var err error
+	r := lib.NewRunner(Description, "<char|charcode>")
+	r.ArgsAreFiles = true
+	r.Parse()
+	char = r.Params[0]
+	if strings.HasPrefix(strings.ToLower(char), `\u`) {
+		char = strings.Replace(strings.ToLower(char), `\u`, "", -1)
+		char, err = code2char(char)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+	} else if strings.HasPrefix(strings.ToLower(char), "u") {
+		char = strings.Replace(strings.ToLower(char), "u", "", -1)
+		char, err = code2char(char)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+	} else if strings.HasPrefix(strings.ToLower(char), "u+") {
+		char = strings.Replace(strings.ToLower(char), "u", "", -1)
+		char, err = code2char(char)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	err = r.RunConverter(process)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
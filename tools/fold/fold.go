@@ -0,0 +1,47 @@
+package fold
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Fold each line into a search key: remove diacritics, replace special letters (ø => o, ß => ss, æ => ae, ł => l), and optionally case fold"
+
+// Main runs the fold command, using the command line arguments in os.Args
+func Main() {
+	caseFold := flag.Bool("c", false, "Case fold (default false)")
+	tableFile := flag.String("t", "", "Read special letters from `file`: tab separated character and replacement; the entries are added to the built-in table, replacing existing entries")
+	marksOnly := flag.Bool("m", false, "Remove diacritics only, without replacing special letters (default false)")
+
+	r := lib.NewRunner(Description)
+	r.Examples = []string{
+		fmt.Sprintf("%s -c Åsa ASA Asa", r.Name),
+		fmt.Sprintf("%s -t my_letters.tsv names.txt", r.Name),
+	}
+	r.Parse()
+
+	folder := unicode.NewFolder()
+	folder.CaseFold = *caseFold
+	if *marksOnly {
+		folder.Special = map[rune]string{}
+	}
+	if *tableFile != "" {
+		table, err := unicode.ReadFoldTable(*tableFile)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		for ch, rep := range table {
+			folder.Special[ch] = rep
+		}
+	}
+
+	err := r.RunConverter(folder.Fold)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
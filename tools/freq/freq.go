@@ -0,0 +1,86 @@
+package freq
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+)
+
+// Description is a one-line description of the command
+const Description = "Print the frequency of each input line, sorted by frequency"
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+func sortByValue(freqMap map[string]int64) []string {
+	res := []string{}
+	for s := range freqMap {
+		if !contains(res, s) {
+			res = append(res, s)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return freqMap[res[i]] > freqMap[res[j]] })
+	return res
+}
+
+func prcntFmt(value int64, total int) string {
+	return fmt.Sprintf("%.2f%%", math.Round(float64(value*100))/float64(total))
+}
+
+// Main runs the freq command, using the command line arguments in os.Args
+func Main() {
+	freqRight := flag.Bool("r", false, "print frequency on the right hand side (default: false)")
+	percentage := flag.Bool("p", false, "print percentage (default: false)")
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadRecords
+	r.ArgsAreFiles = true
+	r.FormatFlag()
+	r.Parse()
+
+	freq := make(map[string]int64)
+	total := 0
+	err := r.ForEachRecord(func(s string) error {
+		freq[s]++
+		total++
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Couldn't compute : %v", err)
+	}
+	header := []string{"freq", "line"}
+	if *freqRight || *percentage {
+		header = []string{"line", "freq"}
+	}
+	if *percentage {
+		header = append(header, "percentage")
+	}
+	tw := r.NewTableWriter(header...)
+	for _, s := range sortByValue(freq) {
+		row := []any{freq[s], s}
+		if *freqRight || *percentage {
+			row = []any{s, freq[s]}
+		}
+		if *percentage {
+			row = append(row, prcntFmt(freq[s], total))
+		}
+		if err := tw.Write(row...); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
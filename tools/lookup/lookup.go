@@ -0,0 +1,285 @@
+package lookup
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	hio "github.com/HannaLindgren/go-utils/io"
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script to filter lines in a file based on a certain column, using a list of which field values to print"
+
+// lookup holds the options and the loaded contents for a lookup run
+type lookup struct {
+	fsys fs.FS
+	out  io.Writer
+
+	fieldSep string
+	ignoreCase,
+	printMissing,
+	trimSpace bool
+	// maxDistance is the maximum edit distance for fuzzy matching (0 means exact matching)
+	maxDistance int
+	// key is an optional key function, such as a phonetic key: field values are matched by key
+	key func(string) string
+
+	// static/dynamic variables
+	lines    map[int]map[string][]string
+	fuzzy    map[int]*str.FuzzyIndex
+	indices  []int
+	nPrinted int
+	nFound   int
+	missing  []string
+}
+
+func newLookup(fsys fs.FS, out io.Writer) *lookup {
+	return &lookup{
+		fsys:     fsys,
+		out:      out,
+		fieldSep: "\t",
+		lines:    make(map[int]map[string][]string),
+		fuzzy:    make(map[int]*str.FuzzyIndex),
+	}
+}
+
+func (l *lookup) loadFieldIndices(fields string) error {
+	for _, s := range strings.Split(fields, ",") {
+		i0, err := strconv.ParseInt(s, 10, 64)
+		i := int(i0 - 1)
+		if err != nil {
+			return fmt.Errorf("couldn't parse field index <%s> in input definition <%s>", s, fields)
+		}
+		l.indices = append(l.indices, i)
+		l.lines[i] = make(map[string][]string)
+	}
+	return nil
+}
+
+// loadContents reads the input file; if inputFile is nil, the contents are read from stdin
+func (l *lookup) loadContents(inputFile *string, stdin io.Reader) error {
+	var lns []string
+	if inputFile != nil {
+		r, closer, err := hio.OpenFileReaderFS(l.fsys, *inputFile)
+		defer closer.Close()
+		if err != nil {
+			return fmt.Errorf("couldn't read from content file %s: %v", *inputFile, err)
+		}
+		scan := bufio.NewScanner(r)
+		for scan.Scan() {
+			lns = append(lns, scan.Text())
+
+		}
+	} else {
+		b, err := ioutil.ReadAll(bufio.NewReader(stdin))
+		if err != nil {
+			return fmt.Errorf("couldn't read contents from stdin: %v", err)
+		}
+		lns = strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	}
+	for _, line := range lns {
+		if l.trimSpace {
+			line = strings.TrimSpace(line)
+		}
+		for i, f := range strings.Split(line, l.fieldSep) {
+			if l.ignoreCase {
+				f = strings.ToUpper(f)
+			}
+			if l.key != nil {
+				f = l.key(f)
+			}
+			if _, ok := l.lines[i]; !ok {
+				l.lines[i] = make(map[string][]string)
+				l.lines[i][f] = []string{}
+			}
+			l.lines[i][f] = append(l.lines[i][f], line)
+		}
+	}
+	if l.maxDistance > 0 {
+		l.buildFuzzyIndices()
+	}
+	return nil
+}
+
+// buildFuzzyIndices creates a fuzzy index of the field values for each field index to check
+func (l *lookup) buildFuzzyIndices() {
+	for _, i := range l.indices {
+		ix := str.NewFuzzyIndex(nil)
+		for f := range l.lines[i] {
+			ix.Add(f)
+		}
+		l.fuzzy[i] = ix
+	}
+}
+
+// printFuzzyMatches prints the lines with field values within the maximum edit distance from the field, prefixed by the field and the distance; it returns true if any lines were found
+func (l *lookup) printFuzzyMatches(field, field0 string) bool {
+	found := false
+	for _, i := range l.indices {
+		matches := l.fuzzy[i].Search(field, l.maxDistance)
+		for _, m := range matches {
+			for _, line := range l.lines[i][m.Word] {
+				found = true
+				l.nPrinted++
+				if !l.printMissing {
+					fmt.Fprintf(l.out, "%s\t%d\t%s\n", field0, m.Distance, line)
+				}
+			}
+		}
+		if len(matches) > 0 {
+			l.nFound++
+		}
+	}
+	return found
+}
+
+// readFields reads the field values to look up, from a file or (if there is no such file) from the input string itself
+func (l *lookup) readFields(fNameOrString string) error {
+	var fields []string
+	if !hio.IsFileFS(l.fsys, fNameOrString) {
+		fields = append(fields, fNameOrString)
+	} else {
+		r, closer, err := hio.OpenFileReaderFS(l.fsys, fNameOrString)
+		defer closer.Close()
+		if err != nil {
+			return fmt.Errorf("couldn't read field file %s: %v", fNameOrString, err)
+		}
+		scan := bufio.NewScanner(r)
+		for scan.Scan() {
+			fields = append(fields, scan.Text())
+		}
+	}
+	for _, field0 := range fields {
+		field := field0
+		if l.ignoreCase {
+			field = strings.ToUpper(field)
+		}
+		if l.trimSpace {
+			field = strings.TrimSpace(field)
+		}
+		if l.key != nil {
+			field = l.key(field)
+		}
+		if l.maxDistance > 0 {
+			if !l.printFuzzyMatches(field, field0) {
+				l.missing = append(l.missing, field0)
+			}
+			continue
+		}
+		found := false
+		for _, i := range l.indices {
+			if val, ok := l.lines[i][field]; ok {
+				for _, line := range val {
+					found = true
+					l.nPrinted++
+					if !l.printMissing {
+						fmt.Fprintln(l.out, line)
+					}
+				}
+				l.nFound++
+			}
+		}
+		if !found {
+			l.missing = append(l.missing, field0)
+		}
+	}
+	return nil
+}
+
+// run performs a lookup, printing the matching lines; if inputFile is nil, the contents are read from stdin
+func (l *lookup) run(inputFile *string, stdin io.Reader, fields, fieldsToPrint string) error {
+	if err := l.loadFieldIndices(fields); err != nil {
+		return err
+	}
+	if err := l.loadContents(inputFile, stdin); err != nil {
+		return err
+	}
+	return l.readFields(fieldsToPrint)
+}
+
+// printMissingItems prints the field values that were not found, if the print missing option is set
+func (l *lookup) printMissingItems() {
+	if l.printMissing && len(l.missing) > 0 {
+		for _, s := range l.missing {
+			fmt.Fprintf(l.out, "%s\n", s)
+		}
+	}
+}
+
+// Main runs the lookup command, using the command line arguments in os.Args
+func Main() {
+	cmdname := filepath.Base(os.Args[0])
+	l := newLookup(hio.OSFS{}, os.Stdout)
+	flag.BoolVar(&l.ignoreCase, "i", false, "ignore case (default false)")
+	flag.BoolVar(&l.trimSpace, "t", false, "trim lines (default false)")
+	flag.BoolVar(&l.printMissing, "m", false, "print missing items only (default false)")
+	flag.StringVar(&l.fieldSep, "f", "\t", "field separator")
+	keyName := flag.String("key", "", fmt.Sprintf("match field values by phonetic key instead of exact text: %s", strings.Join(str.PhoneticKeyNames(), ", ")))
+	flag.IntVar(&l.maxDistance, "k", 0, "fuzzy matching: print lines with field values within this edit distance, prefixed by the field value to print and the distance (default 0, exact matching)")
+
+	var printUsage = func() {
+		fmt.Fprintln(os.Stderr, Description)
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Usage:")
+		fmt.Fprintln(os.Stderr, cmdname+" <input file> <field indices to check> <file with list of field values to print>")
+		fmt.Fprintln(os.Stderr, " OR")
+		fmt.Fprintln(os.Stderr, "cat <input> | "+cmdname+" <field indices to check> <file with list of field values to print>")
+		fmt.Fprintln(os.Stderr, "\nOptional flags:")
+		flag.PrintDefaults()
+	}
+
+	flag.Usage = func() {
+		printUsage()
+		os.Exit(0)
+	}
+
+	flag.Parse()
+	if l.maxDistance < 0 {
+		log.Fatalf("invalid edit distance for -k: %d", l.maxDistance)
+	}
+	if *keyName != "" {
+		key, err := str.PhoneticKey(*keyName)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		l.key = key
+	}
+
+	var inputFile *string
+	var fields, fieldsToPrint string
+	if flag.NArg() == 2 {
+		fields = flag.Arg(0)
+		fieldsToPrint = flag.Arg(1)
+	} else if flag.NArg() == 3 {
+		inpF := flag.Arg(0)
+		inputFile = &inpF
+		fields = flag.Arg(1)
+		fieldsToPrint = flag.Arg(2)
+	} else {
+		printUsage()
+		os.Exit(0)
+	}
+
+	if err := l.run(inputFile, os.Stdin, fields, fieldsToPrint); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var foundNotPrinted string
+	if l.printMissing && l.nFound > 0 {
+		foundNotPrinted = " *** NOT PRINTED"
+	}
+	fmt.Fprintf(os.Stderr, "Found %d entries/%d lines%s\n", l.nFound, l.nPrinted, foundNotPrinted)
+	fmt.Fprintf(os.Stderr, "Missing entries: %d\n", len(l.missing))
+
+	l.printMissingItems()
+}
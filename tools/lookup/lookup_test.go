@@ -0,0 +1,120 @@
+package lookup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+var fsExpGot = "expected: %#v ; got: %#v"
+
+var testFS = fstest.MapFS{
+	"lex.tsv":   {Data: []byte("hej\th E j\nhallå\th a l O:\nHEJ\th E j\n")},
+	"words.txt": {Data: []byte("hej\nbanan\n")},
+}
+
+func TestLookupFromFile(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := newLookup(testFS, out)
+	inputFile := "lex.tsv"
+	err := l.run(&inputFile, nil, "1", "words.txt")
+	if err != nil {
+		t.Fatalf("Got error from run: %v", err)
+	}
+	exp := "hej\th E j\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if l.nFound != 1 || l.nPrinted != 1 {
+		t.Errorf(fsExpGot, []int{1, 1}, []int{l.nFound, l.nPrinted})
+	}
+	expMissing := []string{"banan"}
+	if strings.Join(l.missing, ",") != strings.Join(expMissing, ",") {
+		t.Errorf(fsExpGot, expMissing, l.missing)
+	}
+}
+
+func TestLookupFromStdin(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := newLookup(testFS, out)
+	l.ignoreCase = true
+	stdin := strings.NewReader("hej\th E j\nhallå\th a l O:\nHEJ\th E j\n")
+	err := l.run(nil, stdin, "1", "hej")
+	if err != nil {
+		t.Fatalf("Got error from run: %v", err)
+	}
+	exp := "hej\th E j\nHEJ\th E j\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestLookupPrintMissing(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := newLookup(testFS, out)
+	l.printMissing = true
+	inputFile := "lex.tsv"
+	err := l.run(&inputFile, nil, "1", "words.txt")
+	if err != nil {
+		t.Fatalf("Got error from run: %v", err)
+	}
+	l.printMissingItems()
+	exp := "banan\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestLookupFuzzy(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := newLookup(testFS, out)
+	l.maxDistance = 1
+	stdin := strings.NewReader("hej\th E j\nhallå\th a l O:\nHEJ\th E j\nhaj\th a j\n")
+	err := l.run(nil, stdin, "1", "hoj")
+	if err != nil {
+		t.Fatalf("Got error from run: %v", err)
+	}
+	exp := "hoj\t1\thaj\th a j\nhoj\t1\thej\th E j\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if l.nFound != 1 || l.nPrinted != 2 || len(l.missing) != 0 {
+		t.Errorf(fsExpGot, []int{1, 2, 0}, []int{l.nFound, l.nPrinted, len(l.missing)})
+	}
+
+	out.Reset()
+	l = newLookup(testFS, out)
+	l.maxDistance = 1
+	l.ignoreCase = true
+	inputFile := "lex.tsv"
+	err = l.run(&inputFile, nil, "1", "words.txt")
+	if err != nil {
+		t.Fatalf("Got error from run: %v", err)
+	}
+	exp = "hej\t0\thej\th E j\nhej\t0\tHEJ\th E j\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	expMissing := []string{"banan"}
+	if strings.Join(l.missing, ",") != strings.Join(expMissing, ",") {
+		t.Errorf(fsExpGot, expMissing, l.missing)
+	}
+}
+
+func TestLookupPhoneticKey(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := newLookup(testFS, out)
+	l.key = str.Soundex
+	stdin := strings.NewReader("Robert\tr a: b @ r t\nRubin\tr u: b I n\n")
+	err := l.run(nil, stdin, "1", "Rupert")
+	if err != nil {
+		t.Fatalf("Got error from run: %v", err)
+	}
+	exp := "Robert\tr a: b @ r t\n"
+	if got := out.String(); got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
@@ -0,0 +1,21 @@
+package nfc
+
+import (
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script for canonical composition of text data (non-destructive conversion)"
+
+// Main runs the nfc command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.Parse()
+	err := r.RunConverter(unicode.NFC)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package nfd
+
+import (
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script for canonical decomposition of text data (non-destructive conversion)"
+
+// Main runs the nfd command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.Parse()
+	err := r.RunConverter(unicode.NFD)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
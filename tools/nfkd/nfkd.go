@@ -0,0 +1,21 @@
+package nfkd
+
+import (
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script for compatibility decomposition of text data (destructive conversion)"
+
+// Main runs the nfkd command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.Parse()
+	err := r.RunConverter(unicode.NFKD)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package printcolumns
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+)
+
+// Description is a one-line description of the command
+const Description = "Print selected columns based on file header"
+
+type field struct {
+	req      reqField
+	outIndex int
+	inIndex  int
+}
+
+type reqField struct {
+	index    int
+	normName string
+	name     string
+}
+
+var printedHeaders = map[string]bool{}
+
+func process(out io.Writer, requestedFields []reqField, lines []string) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("no input lines")
+	}
+	header := lines[0]
+	if !*caseSens {
+		header = strings.ToLower(header)
+	}
+	existingFields := strings.Split(header, fieldSep)
+
+	// save printable indices
+	var colsToPrint = []field{}
+	var nColsToPrint = 0
+	for i, s := range existingFields {
+		for _, rf := range requestedFields {
+			if rf.normName == s {
+				col := field{
+					req:     rf,
+					inIndex: i,
+				}
+				if *preserveOrder {
+					col.outIndex = nColsToPrint
+				} else {
+					col.outIndex = rf.index
+				}
+				colsToPrint = append(colsToPrint, col)
+				nColsToPrint++
+			}
+		}
+	}
+
+	// check for invalid columns in input flag
+	for _, rf := range requestedFields {
+		if !slices.Contains(existingFields, rf.normName) {
+			return fmt.Errorf("requested field '%s' does not exist in input data", rf.name)
+		}
+	}
+
+	for li, l := range lines {
+		if li == 0 && *skipHeader {
+			continue
+		}
+		outFS := []string{}
+		for len(outFS) < nColsToPrint {
+			outFS = append(outFS, "")
+		}
+		for i, f := range strings.Split(l, fieldSep) {
+			for _, ff := range colsToPrint {
+				if ff.inIndex == i {
+					outFS[ff.outIndex] = f
+				}
+			}
+		}
+		outS := strings.Join(outFS, "\t")
+		if li == 0 {
+			if printedHeaders[outS] {
+				continue
+			}
+			printedHeaders[outS] = true
+			if len(printedHeaders) > 1 {
+				return fmt.Errorf("Mismatching output headers: %s", strings.Join(maps.Keys(printedHeaders), "\n"))
+			}
+		}
+		fmt.Fprintln(out, outS)
+	}
+	return nil
+}
+
+// options
+var caseSens, skipHeader, preserveOrder *bool
+var fieldSep string
+
+// Main runs the print_columns command, using the command line arguments in os.Args
+func Main() {
+
+	caseSens = flag.Bool("c", false, "Case sensitive column headers")
+	fieldSepFlag := flag.String("s", "<tab>", "Field `separator` for input data")
+	headerFieldSep := flag.String("cs", ",", "Field `separator` for requested column names")
+	skipHeader = flag.Bool("H", false, "Skip output header")
+	preserveFN := "o"
+	preserveOrder = flag.Bool(preserveFN, false, "Preserve input file's column ordering")
+	repeatFN := "r"
+	allowRepeatedColumns := flag.Bool(repeatFN, false, "Allow repeated output fields")
+	verb := flag.Bool("v", false, "Verbose output")
+
+	r := lib.NewRunner(Description, "<requested columns>")
+	r.Mode = lib.ReadInputs
+	// -o is the preserve order flag, instead of the standard output file flag
+	r.NoOutputFlag = true
+	r.ArgsAreFiles = true
+	r.Examples = []string{fmt.Sprintf("%s orth,country /tmp/sourcefile.txt", r.Name)}
+	r.Parse()
+
+	fieldSep = *fieldSepFlag
+	if fieldSep == "<tab>" {
+		fieldSep = "\t"
+	}
+
+	if *preserveOrder && *allowRepeatedColumns {
+		fmt.Fprintf(os.Stderr, "[warning] Flags -%s and -%s makes little sense to use in combination\n", preserveFN, repeatFN)
+	}
+
+	var requestedFieldsString = r.Params[0]
+	var requestedFields = []reqField{}
+	var seenRequestedFields = map[string]bool{}
+	//for i, name := range columnSplitRE.Split(requestedFieldsString, -1) {
+	for i, name := range strings.Split(requestedFieldsString, *headerFieldSep) {
+		var key = name
+		if !*caseSens {
+			key = strings.ToLower(key)
+		}
+		f := reqField{index: i, normName: key, name: name}
+		if _, repeated := seenRequestedFields[f.normName]; repeated && !*allowRepeatedColumns {
+			fmt.Fprintf(os.Stderr, "[error] Repeated columns in request: %s (use flag -%s to allow repeated columns)\n\n", key, repeatFN)
+			r.PrintUsage()
+			os.Exit(1)
+		}
+		requestedFields = append(requestedFields, f)
+		seenRequestedFields[f.normName] = true
+	}
+
+	if *verb {
+		fmt.Fprintf(os.Stderr, "Separator: %#v\n", *fieldSepFlag)
+		fmt.Fprintf(os.Stderr, "Requested fields: %s\n", requestedFieldsString)
+	}
+
+	err := r.ForEachInput(func(in lib.Input) error {
+		if *verb {
+			fmt.Fprintf(os.Stderr, "Reading file: %s\n", in.Name)
+		}
+		bts, err := io.ReadAll(in.Reader)
+		if err != nil {
+			return fmt.Errorf("failed to read from %s: %v", in.Name, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(bts), "\n"), "\n")
+		err = process(r.Out, requestedFields, lines)
+		if err != nil {
+			return fmt.Errorf("failed to process %s: %v", in.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %v\n", err)
+		os.Exit(1)
+	}
+	if err := r.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package printlen
+
+import (
+	"flag"
+	"log"
+	"regexp"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Prints the length of each input line"
+
+var wSplitRe = regexp.MustCompile("[ ,()/-]")
+
+func process(tw *lib.TableWriter, s string, length func(string) int) error {
+	if s != "" {
+		wds := wSplitRe.Split(s, -1)
+		return tw.Write(length(s), len(wds), s)
+	}
+	if tw.Format() == lib.TextFormat {
+		// empty input lines are printed as empty lines
+		return tw.Write()
+	}
+	return tw.Write(0, 0, s)
+}
+
+// Main runs the print_len command, using the command line arguments in os.Args
+func Main() {
+	width := flag.Bool("w", false, "Print the display width (terminal columns, counting wide CJK characters and emoji as two, and combining marks as zero) instead of the number of characters (default false)")
+
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadRecords
+	r.FormatFlag()
+	r.Parse()
+
+	length, header := func(s string) int { return len([]rune(s)) }, "chars"
+	if *width {
+		length, header = str.Width, "width"
+	}
+	tw := r.NewTableWriter(header, "words", "line")
+	err := r.ForEachRecord(func(s string) error {
+		return process(tw, s, length)
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := tw.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package recode
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script to convert text between charsets"
+
+// Main runs the recode command, using the command line arguments in os.Args
+func Main() {
+	from := flag.String("from", "UTF-8", "Input charset")
+	to := flag.String("to", "UTF-8", "Output charset")
+	fixMojibake := flag.Bool("m", false, "Detect and repair mojibake, e.g. Ã¥ => å (default false)")
+	listCharsets := flag.Bool("l", false, "List supported charsets and exit")
+	verb := flag.Bool("v", false, "Verbose output: report repaired lines to stderr (default false)")
+
+	r := lib.NewRunner(Description + ". Lines with invalid UTF-8 are reported to stderr.")
+	r.Mode = lib.ReadInputs
+	r.ArgsAreFiles = true
+	r.Examples = []string{
+		fmt.Sprintf("%s -from ISO-8859-1 /tmp/latin1.txt", r.Name),
+		fmt.Sprintf("%s -m /tmp/double_encoded.txt", r.Name),
+	}
+	r.Parse()
+
+	if *listCharsets {
+		for _, name := range unicode.CharsetNames() {
+			fmt.Println(name)
+		}
+		os.Exit(0)
+	}
+
+	fromEnc, err := unicode.LookupCharset(*from)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	toEnc, err := unicode.LookupCharset(*to)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	err = r.ForEachInput(func(in lib.Input) error {
+		recoder := unicode.Recoder{From: fromEnc, To: toEnc, FixMojibake: *fixMojibake}
+		recoder.InvalidLine = func(lineNo int, line string) {
+			fmt.Fprintf(os.Stderr, "%s:%d: invalid UTF-8: %q\n", in.Name, lineNo, line)
+		}
+		if *verb {
+			recoder.FixedLine = func(lineNo int, line, fixed string) {
+				fmt.Fprintf(os.Stderr, "%s:%d: repaired mojibake: %s => %s\n", in.Name, lineNo, line, fixed)
+			}
+		}
+		if err := recoder.Recode(in.Reader, r.Out); err != nil {
+			return fmt.Errorf("%s: %v", in.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package renamefiles
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+)
+
+// Description is a one-line description of the command
+const Description = "Rename multiple files using regexp"
+
+const cmdname = "rename_files"
+
+func exists(f string) bool {
+	_, err := os.Stat(f)
+	return !os.IsNotExist(err)
+}
+
+// Main runs the rename_files command, using the command line arguments in os.Args
+func Main() {
+	//prompt := flag.Bool("p", false, "Prompt before overwriting existing files")
+
+	var printUsage = func() {
+		fmt.Fprintln(os.Stderr, Description)
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintf(os.Stderr, "Usage: %s <from regexp> <to string> <files>\n", cmdname)
+		// fmt.Fprintln(os.Stderr, "\nOptional flags:")
+		// flag.PrintDefaults()
+	}
+
+	flag.Usage = printUsage
+
+	flag.Parse()
+
+	if flag.NArg() < 3 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	fromS := flag.Args()[0]
+	toS := flag.Args()[1]
+	files := flag.Args()[2:]
+
+	fromRE, err := regexp.Compile(fromS)
+	if err != nil {
+		log.Fatalf("Regexp compile failed: %v", err)
+	}
+
+	for _, oldF := range files {
+		newF := fromRE.ReplaceAllString(oldF, toS)
+		if newF == oldF {
+			fmt.Fprintln(os.Stderr, "Skipping", oldF)
+			continue
+		}
+		fmt.Fprintln(os.Stdout, oldF, "=>", newF)
+		err := os.Rename(oldF, newF)
+		if err != nil {
+			log.Fatalf("Rename failed: %v", err)
+		}
+	}
+
+}
@@ -0,0 +1,43 @@
+package replace
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Replace strings in each line using find/replace rules from a tab separated file, in a single pass (leftmost-longest match, independent of rule order)"
+
+// Main runs the replace command, using the command line arguments in os.Args
+func Main() {
+	ruleFile := flag.String("r", "", "Read find/replace rules from `file`: tab separated string to find and replacement (required)")
+	wholeWords := flag.Bool("w", false, "Replace whole words only (default false)")
+
+	r := lib.NewRunner(Description)
+	r.Examples = []string{
+		fmt.Sprintf("%s -r abbreviations.tsv text.txt", r.Name),
+		fmt.Sprintf("%s -w -r names.tsv -cols 2 names.tsv", r.Name),
+	}
+	r.Parse()
+
+	if *ruleFile == "" {
+		fmt.Fprint(os.Stderr, "Missing required flag -r (rule file)\n")
+		r.PrintUsage()
+		os.Exit(1)
+	}
+	rules, err := str.ReadReplaceRules(*ruleFile)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	replacer := str.NewMultiReplacer(rules, str.MatcherOptions{WholeWords: *wholeWords})
+
+	err = r.RunConverter(replacer.Replace)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
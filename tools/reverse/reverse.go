@@ -0,0 +1,23 @@
+package reverse
+
+import (
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Reverse each line, keeping grapheme clusters (such as letters with combining marks, flags and emoji sequences) intact"
+
+// Main runs the reverse command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.ArgsAreFiles = true
+	r.Parse()
+	err := r.RunConverter(strings.Reverse)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+}
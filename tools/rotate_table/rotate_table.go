@@ -0,0 +1,47 @@
+package rotatetable
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+)
+
+// Description is a one-line description of the command
+const Description = "Rotate a tab-separated table, so that rows become columns"
+
+var fieldSep = "\t"
+
+// Main runs the rotate_table command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadInputs
+	r.ArgsAreFiles = true
+	r.Parse()
+	err := r.ForEachInput(func(in lib.Input) error {
+		scan := bufio.NewScanner(in.Reader)
+		rows := [][]string{}
+		for scan.Scan() {
+			s := scan.Text()
+			fs := strings.Split(s, fieldSep)
+			for i, field := range fs {
+				for len(rows) <= i {
+					rows = append(rows, []string{})
+				}
+				rows[i] = append(rows[i], field)
+			}
+		}
+		for _, row := range rows {
+			fmt.Fprintln(r.Out, strings.Join(row, fieldSep))
+		}
+		return scan.Err()
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
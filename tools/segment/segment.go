@@ -0,0 +1,104 @@
+package segment
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	str "github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Split text into sentences or words, handling abbreviations, decimals, URLs, e-mail addresses and ellipses"
+
+// Main runs the segment command, using the command line arguments in os.Args
+func Main() {
+	lang := flag.String("lang", "", fmt.Sprintf("Language `code` for the built-in abbreviation list: %s (default: no abbreviations, except initials)", strings.Join(str.AbbreviationLanguages(), ", ")))
+	abbrevFile := flag.String("a", "", "Read additional abbreviations from `file`, one per line")
+	words := flag.Bool("w", false, "Print words, one per line, instead of sentences (default false)")
+	tokenized := flag.Bool("t", false, "Print sentences, one per line, with the words separated by a space (default false)")
+	offsets := flag.Bool("offsets", false, "Print the start and end offsets (in characters) and the kind of each segment (default false)")
+
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadInputs
+	r.Examples = []string{
+		fmt.Sprintf("%s -lang sv article.txt", r.Name),
+		fmt.Sprintf("%s -lang en -t -- 'Dr. Smith paid $3.50. He left at 5 p.m.'", r.Name),
+		fmt.Sprintf("%s -w -offsets -format jsonl article.txt", r.Name),
+	}
+	r.FormatFlag()
+	r.Parse()
+
+	if *words && *tokenized {
+		fmt.Fprint(os.Stderr, "Flags -w and -t cannot be combined\n")
+		r.PrintUsage()
+		os.Exit(1)
+	}
+	sg, err := str.NewSegmenter(*lang)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *abbrevFile != "" {
+		abbrevs, err := str.ReadAbbreviations(*abbrevFile)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		sg.AddAbbreviations(abbrevs...)
+	}
+
+	header := []string{"text"}
+	if *offsets {
+		header = []string{"start", "end", "kind", "text"}
+	}
+	tw := r.NewTableWriter(header...)
+	write := func(tok str.Token, text string) error {
+		if tw.Format() == lib.TextFormat {
+			// one segment per line
+			text = strings.Join(strings.Fields(text), " ")
+		}
+		if *offsets {
+			return tw.Write(tok.RuneStart, tok.RuneEnd, tok.Kind.String(), text)
+		}
+		return tw.Write(text)
+	}
+	err = r.ForEachInput(func(in lib.Input) error {
+		text, err := in.Text()
+		if err != nil {
+			return err
+		}
+		if *words {
+			for _, tok := range sg.Words(text) {
+				if err := write(tok, tok.String); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, tok := range sg.Sentences(text) {
+			s := tok.String
+			if *tokenized {
+				ws := []string{}
+				for _, w := range sg.Words(s) {
+					ws = append(ws, w.String)
+				}
+				s = strings.Join(ws, " ")
+			}
+			if err := write(tok, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := tw.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package sum
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+)
+
+// Description is a one-line description of the command
+const Description = "Sum and mean of the input numbers (one float/integer per line)"
+
+// Main runs the sum command, using the command line arguments in os.Args
+func Main() {
+	sum := 0.0
+	n := 0
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadRecords
+	r.ArgsAreFiles = true
+	r.FormatFlag()
+	r.Parse()
+	// skipped lines are reported in the output for plain text, and to stderr for the other formats, to keep the output valid
+	skipped := r.Out
+	if r.Format() != lib.TextFormat {
+		skipped = r.Errors
+	}
+	err := r.ForEachRecord(func(line string) error {
+		if strings.TrimSpace(line) == "" {
+			return nil
+		}
+		if strings.Contains(line, "\t") {
+			fmt.Fprintf(skipped, "Skipping %s\n", line)
+			return nil
+		}
+		if strings.Contains(line, "#") {
+			fmt.Fprintf(skipped, "Skipping %s\n", line)
+			return nil
+		}
+		line = strings.TrimSpace(strings.Replace(line, ",", ".", -1))
+		line = strings.Replace(line, " ", "", -1)
+		asNum, err := strconv.ParseFloat(line, 64)
+		n++
+		if err != nil {
+			return fmt.Errorf("Couldn't parse number from %s : %v", line, err)
+		}
+		sum = sum + asNum
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	mean := sum / float64(n)
+	if r.Format() == lib.TextFormat {
+		fmt.Fprintf(r.Out, "items   %15d\n", n)
+		fmt.Fprintf(r.Out, "sum     %15.2f\n", sum)
+		fmt.Fprintf(r.Out, "mean    %15.2f\n", mean)
+	} else {
+		tw := r.NewTableWriter("items", "sum", "mean")
+		if err := tw.Write(n, sum, mean); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if err := tw.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := r.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
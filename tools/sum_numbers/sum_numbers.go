@@ -0,0 +1,73 @@
+package sumnumbers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	hio "github.com/HannaLindgren/go-utils/io"
+)
+
+// Description is a one-line description of the command
+const Description = "Sum, number of items and mean of the input numbers (one per line, decimal comma allowed, lines starting with # are skipped)"
+
+var nItems int
+var sum float64
+
+func process(lines []string) {
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if len(l) == 0 || strings.HasPrefix(l, "#") {
+			continue
+		}
+		l = strings.Replace(l, ",", ".", -1)
+		nItems++
+		f, err := strconv.ParseFloat(l, 10)
+		if err != nil {
+			log.Fatalf("Parse float failed for %v: %v", l, err)
+		}
+		sum += f
+	}
+}
+
+// Main runs the sum_numbers command (installed separately as cmd/sum), using the command line arguments in os.Args
+func Main() {
+	cmdname := filepath.Base(os.Args[0])
+	var printUsage = func() {
+		fmt.Fprintln(os.Stderr, Description)
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintf(os.Stderr, "Usage: %s <file>\n", cmdname)
+		fmt.Fprintln(os.Stderr, "       OR")
+		fmt.Fprintf(os.Stderr, "       cat <file> | %s\n", cmdname)
+	}
+
+	if len(os.Args) > 1 && strings.HasPrefix(os.Args[1], "-h") {
+		printUsage()
+		os.Exit(0)
+	}
+
+	if len(os.Args) == 1 {
+		lines, err := hio.ReadStdinToLines()
+		if err != nil {
+			log.Fatalf("Read failed for stdin: %v", err)
+		}
+		process(lines)
+	} else {
+		for _, f := range os.Args[1:] {
+			lines, err := hio.ReadFileToLines(f)
+			if err != nil {
+				log.Fatalf("Read failed for file %s: %v", f, err)
+			}
+			process(lines)
+		}
+	}
+
+	mean := sum / float64(nItems)
+	fmt.Fprintf(os.Stdout, "sum:   %v\n", sum)
+	fmt.Fprintf(os.Stdout, "items: %v\n", nItems)
+	fmt.Fprintf(os.Stdout, "mean:  %v\n", mean)
+
+}
@@ -0,0 +1,48 @@
+package swapfields
+
+import (
+	//"bufio"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+)
+
+// Description is a one-line description of the command
+const Description = "Print the selected fields of each line, in the requested order (field numbers start at 1)"
+
+var fieldSep = "\t"
+var is = []int64{}
+
+func process(s string) string {
+	if s == "" {
+		return s
+	}
+	fs := strings.Split(s, fieldSep)
+	output := []string{}
+	for _, i := range is {
+		output = append(output, fs[i])
+	}
+	return strings.Join(output, fieldSep)
+}
+
+// Main runs the swap_fields command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description, "<fields-to-print>")
+	r.ArgsAreFiles = true
+	r.Parse()
+	for _, f := range strings.Split(r.Params[0], ",") {
+		i, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+		if err != nil {
+			log.Fatalf("Couldn't parse string to int : %v", err)
+		}
+		is = append(is, i-1)
+	}
+
+	err := r.RunConverter(process)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+}
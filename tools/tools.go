@@ -0,0 +1,116 @@
+// Package tools holds the commands of scripts and cmd as importable packages, so that they can be bundled into a single multi-call binary (see cmd/goutils). Each command package has a Main function, using the command line arguments in os.Args, and a one-line Description.
+package tools
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/tools/capitalize"
+	"github.com/HannaLindgren/go-utils/tools/compare_files"
+	"github.com/HannaLindgren/go-utils/tools/compare_line_by_line"
+	"github.com/HannaLindgren/go-utils/tools/csv2xlsx"
+	"github.com/HannaLindgren/go-utils/tools/downcase"
+	"github.com/HannaLindgren/go-utils/tools/fileserver"
+	"github.com/HannaLindgren/go-utils/tools/find_unicode"
+	"github.com/HannaLindgren/go-utils/tools/fold"
+	"github.com/HannaLindgren/go-utils/tools/freq"
+	"github.com/HannaLindgren/go-utils/tools/lookup"
+	"github.com/HannaLindgren/go-utils/tools/mdiff"
+	"github.com/HannaLindgren/go-utils/tools/nfc"
+	"github.com/HannaLindgren/go-utils/tools/nfd"
+	"github.com/HannaLindgren/go-utils/tools/nfkc"
+	"github.com/HannaLindgren/go-utils/tools/nfkd"
+	"github.com/HannaLindgren/go-utils/tools/print_columns"
+	"github.com/HannaLindgren/go-utils/tools/print_len"
+	"github.com/HannaLindgren/go-utils/tools/recode"
+	"github.com/HannaLindgren/go-utils/tools/rename_files"
+	"github.com/HannaLindgren/go-utils/tools/replace"
+	"github.com/HannaLindgren/go-utils/tools/reverse"
+	"github.com/HannaLindgren/go-utils/tools/rotate_table"
+	"github.com/HannaLindgren/go-utils/tools/segment"
+	"github.com/HannaLindgren/go-utils/tools/server"
+	"github.com/HannaLindgren/go-utils/tools/sum"
+	"github.com/HannaLindgren/go-utils/tools/sum_numbers"
+	"github.com/HannaLindgren/go-utils/tools/swap_fields"
+	"github.com/HannaLindgren/go-utils/tools/translit"
+	"github.com/HannaLindgren/go-utils/tools/unicode_for"
+	"github.com/HannaLindgren/go-utils/tools/unicode_info"
+	"github.com/HannaLindgren/go-utils/tools/unicode_tokeniser"
+	"github.com/HannaLindgren/go-utils/tools/upcase"
+	"github.com/HannaLindgren/go-utils/tools/upcase_initial"
+	"github.com/HannaLindgren/go-utils/tools/xlsx2csv"
+)
+
+// Tool is a command that can be run from a multi-call binary
+type Tool struct {
+	// Name is the command name
+	Name string
+	// Description is a one-line description of the command
+	Description string
+	// Main runs the command, using the command line arguments in os.Args
+	Main func()
+}
+
+// All holds all commands, sorted by name
+var All = []Tool{
+	{Name: "capitalize", Description: capitalize.Description, Main: capitalize.Main},
+	{Name: "compare_files", Description: comparefiles.Description, Main: comparefiles.Main},
+	{Name: "compare_line_by_line", Description: comparelinebyline.Description, Main: comparelinebyline.Main},
+	{Name: "csv2xlsx", Description: csv2xlsx.Description, Main: csv2xlsx.Main},
+	{Name: "downcase", Description: downcase.Description, Main: downcase.Main},
+	{Name: "fileserver", Description: fileserver.Description, Main: fileserver.Main},
+	{Name: "find_unicode", Description: findunicode.Description, Main: findunicode.Main},
+	{Name: "fold", Description: fold.Description, Main: fold.Main},
+	{Name: "freq", Description: freq.Description, Main: freq.Main},
+	{Name: "lookup", Description: lookup.Description, Main: lookup.Main},
+	{Name: "mdiff", Description: mdiff.Description, Main: mdiff.Main},
+	{Name: "nfc", Description: nfc.Description, Main: nfc.Main},
+	{Name: "nfd", Description: nfd.Description, Main: nfd.Main},
+	{Name: "nfkc", Description: nfkc.Description, Main: nfkc.Main},
+	{Name: "nfkd", Description: nfkd.Description, Main: nfkd.Main},
+	{Name: "print_columns", Description: printcolumns.Description, Main: printcolumns.Main},
+	{Name: "print_len", Description: printlen.Description, Main: printlen.Main},
+	{Name: "recode", Description: recode.Description, Main: recode.Main},
+	{Name: "rename_files", Description: renamefiles.Description, Main: renamefiles.Main},
+	{Name: "replace", Description: replace.Description, Main: replace.Main},
+	{Name: "reverse", Description: reverse.Description, Main: reverse.Main},
+	{Name: "rotate_table", Description: rotatetable.Description, Main: rotatetable.Main},
+	{Name: "segment", Description: segment.Description, Main: segment.Main},
+	{Name: "server", Description: server.Description, Main: server.Main},
+	{Name: "sum", Description: sum.Description, Main: sum.Main},
+	{Name: "sum_numbers", Description: sumnumbers.Description, Main: sumnumbers.Main},
+	{Name: "swap_fields", Description: swapfields.Description, Main: swapfields.Main},
+	{Name: "translit", Description: translit.Description, Main: translit.Main},
+	{Name: "unicode_for", Description: unicodefor.Description, Main: unicodefor.Main},
+	{Name: "unicode_info", Description: unicodeinfo.Description, Main: unicodeinfo.Main},
+	{Name: "unicode_tokeniser", Description: unicodetokeniser.Description, Main: unicodetokeniser.Main},
+	{Name: "upcase", Description: upcase.Description, Main: upcase.Main},
+	{Name: "upcase_initial", Description: upcaseinitial.Description, Main: upcaseinitial.Main},
+	{Name: "xlsx2csv", Description: xlsx2csv.Description, Main: xlsx2csv.Main},
+}
+
+// Lookup returns the command with the given name. The name may be a path, such as os.Args[0] for a symlink to a multi-call binary; a trailing .exe is ignored.
+func Lookup(name string) (Tool, bool) {
+	name = strings.TrimSuffix(filepath.Base(name), ".exe")
+	for _, t := range All {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Tool{}, false
+}
+
+// PrintHelp prints a list of all commands and their descriptions
+func PrintHelp(w io.Writer) {
+	width := 0
+	for _, t := range All {
+		if len(t.Name) > width {
+			width = len(t.Name)
+		}
+	}
+	for _, t := range All {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, t.Name, t.Description)
+	}
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, tool := range All {
+		if seen[tool.Name] {
+			t.Errorf("Repeated command name: %s", tool.Name)
+		}
+		seen[tool.Name] = true
+		names = append(names, tool.Name)
+		if tool.Description == "" {
+			t.Errorf("Missing description for command %s", tool.Name)
+		}
+		if tool.Main == nil {
+			t.Errorf("Missing main function for command %s", tool.Name)
+		}
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Expected commands to be sorted by name, got %v", names)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"upcase", "/usr/local/bin/upcase", "upcase.exe"} {
+		if tool, ok := Lookup(name); !ok || tool.Name != "upcase" {
+			t.Errorf("Expected to find command upcase for %s", name)
+		}
+	}
+	if _, ok := Lookup("goutils"); ok {
+		t.Errorf("Expected no command for goutils")
+	}
+}
@@ -0,0 +1,72 @@
+package translit
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/strings/translit"
+)
+
+// Description is a one-line description of the command
+const Description = "Transliterate each line using a built-in romanisation table (ISO 9, BGN/PCGN, ALA-LC for Cyrillic, Greek and Arabic) and/or a rule file"
+
+// Main runs the translit command, using the command line arguments in os.Args
+func Main() {
+	table := flag.String("t", "", "Built-in transliteration `table` (see -list)")
+	ruleFile := flag.String("r", "", "Read transliteration rules from `file`: tab separated input, output, and optional preceding and following context (^ = word start, $ = word end); if used with -t, the rules are added to the table, replacing rules with the same input and context")
+	inverse := flag.Bool("inv", false, "Transliterate in the reverse direction, for reversible tables such as iso9 (default false)")
+	listTables := flag.Bool("list", false, "List built-in tables and exit")
+
+	r := lib.NewRunner(Description)
+	r.Examples = []string{
+		fmt.Sprintf("%s -t iso9 Щукин", r.Name),
+		fmt.Sprintf("%s -t iso9 -inv Ŝukin", r.Name),
+		fmt.Sprintf("%s -t bgn-pcgn-el Αθήνα", r.Name),
+		fmt.Sprintf("%s -r my_rules.tsv names.txt", r.Name),
+	}
+	r.Parse()
+
+	if *listTables {
+		for _, t := range translit.Tables {
+			fmt.Printf("%s\t%s\n", t.Name, t.Description)
+		}
+		os.Exit(0)
+	}
+
+	rules := []translit.Rule{}
+	if *table != "" {
+		t, err := translit.LookupTable(*table)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		rules = append(rules, t.Rules...)
+	}
+	if *ruleFile != "" {
+		rs, err := translit.ReadRules(*ruleFile)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		rules = append(rules, rs...)
+	}
+	if len(rules) == 0 {
+		log.Fatalf("no transliteration rules: use -t <table> and/or -r <rule file>")
+	}
+	tr, err := translit.New(rules)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *inverse {
+		tr, err = tr.Inverse()
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+
+	err = r.RunConverter(tr.Transliterate)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
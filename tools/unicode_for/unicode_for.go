@@ -0,0 +1,46 @@
+package unicodefor
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script to convert strings into their unicode representation"
+
+func process(out io.Writer, s string) {
+	for _, r := range s {
+		fmt.Fprint(out, unicode.UnicodeForR(r))
+		if r == unicode.Newline {
+			fmt.Fprintln(out)
+		}
+	}
+}
+
+// Main runs the unicode_for command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadInputs
+	r.Parse()
+	err := r.ForEachInput(func(in lib.Input) error {
+		text, err := in.Text()
+		if err != nil {
+			return err
+		}
+		process(r.Out, text)
+		if in.Literal {
+			fmt.Fprintln(r.Out)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
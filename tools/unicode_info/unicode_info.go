@@ -0,0 +1,67 @@
+package unicodeinfo
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script to retrieve information about input characters: unicode number, unicode name, and character block"
+
+var up unicode.Processor
+
+func process(tw *lib.TableWriter, s string) error {
+	for _, ui := range up.UnicodeInfo(s) {
+		if err := tw.Write(ui.String, ui.Unicode, ui.CharName, ui.CodeBlock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Main runs the unicode_info command, using the command line arguments in os.Args
+func Main() {
+	convertFromUnicodeNumbers := flag.Bool("u", false, "unicode -- convert from unicode numbers (default false)")
+	nfc := flag.Bool("c", false, "NFC -- Canonical composition on all input (default false)")
+	nfd := flag.Bool("d", false, "NFD -- Canonical decomposition on all input (default false)")
+
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadInputs
+	r.FormatFlag()
+	r.Parse()
+
+	if *nfd && *nfc {
+		fmt.Fprintf(os.Stderr, "nfc and nfd options cannot be combined\n")
+		r.PrintUsage()
+		os.Exit(0)
+	}
+
+	up = unicode.Processor{
+		NFC:                       *nfc,
+		NFD:                       *nfd,
+		ConvertFromUnicodeNumbers: *convertFromUnicodeNumbers,
+	}
+
+	tw := r.NewTableWriter("char", "unicode", "name", "block")
+	err := r.ForEachInput(func(in lib.Input) error {
+		text, err := in.Text()
+		if err != nil {
+			return err
+		}
+		return process(tw, text)
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := tw.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package unicodetokeniser
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/unicode"
+)
+
+// Description is a one-line description of the command
+const Description = "Utility script to tokenise strings based on their unicode block"
+
+// Main runs the unicode_tokeniser command, using the command line arguments in os.Args
+func Main() {
+	nfc := flag.Bool("nfc", false, "NFC -- Canonical composition on all input (default false)")
+	nfd := flag.Bool("nfd", false, "NFD -- Canonical decomposition on all input (default false)")
+	splitInputLines := flag.Bool("l", false, "Split input by newline before tokenizing (default for non-JSON output formats)")
+	skipWhiteSpace := flag.Bool("sw", false, "Skip white space (default for non-JSON output formats)")
+	outFmt := flag.String("o", "", "Output `type` (deprecated, use -format): t = tab-separated (text), j = json")
+
+	r := lib.NewRunner(Description)
+	r.Mode = lib.ReadInputs
+	// -o is kept for backward compatibility, instead of the standard output file flag
+	r.NoOutputFlag = true
+	r.FormatFlag()
+	r.Parse()
+
+	switch *outFmt {
+	case "":
+	case "t":
+		flag.Set("format", lib.TextFormat.String())
+	case "j":
+		flag.Set("format", lib.JSONFormat.String())
+	default:
+		fmt.Fprintf(os.Stderr, "invalid output type %s, expected t or j\n", *outFmt)
+		r.PrintUsage()
+		os.Exit(1)
+	}
+
+	if *nfd && *nfc {
+		fmt.Fprintf(os.Stderr, "nfc and nfd options cannot be combined\n")
+		r.PrintUsage()
+		os.Exit(0)
+	}
+
+	jsonO := r.Format().IsJSON()
+	if !jsonO {
+		t := true
+		skipWhiteSpace = &t
+		splitInputLines = &t
+	}
+
+	toker := unicode.Tokenizer{
+		UP: unicode.Processor{
+			NFC: *nfc,
+			NFD: *nfd,
+		},
+		SkipWhiteSpace: *skipWhiteSpace,
+	}
+
+	var process = func(tw *lib.TableWriter, s0 string) error {
+		input := []string{}
+		if *splitInputLines {
+			input = strings.Split(s0, "\n")
+		} else {
+			input = append(input, s0)
+		}
+		for _, s := range input {
+			tokens := toker.Tokenize(s)
+			if jsonO {
+				if err := tw.Write(s, len(tokens), tokens); err != nil {
+					return err
+				}
+				continue
+			}
+			strs := []string{}
+			for _, t := range tokens {
+				strs = append(strs, t.String)
+			}
+			if err := tw.Write(s, len(tokens), strs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := r.NewTableWriter("input", "count", "tokens")
+	err := r.ForEachInput(func(in lib.Input) error {
+		text, err := in.Text()
+		if err != nil {
+			return err
+		}
+		return process(tw, text)
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := tw.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := r.Close(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
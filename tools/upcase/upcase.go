@@ -0,0 +1,25 @@
+package upcase
+
+import (
+	"log"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Convert each input line to upper case"
+
+// Main runs the upcase command, using the command line arguments in os.Args
+func Main() {
+	r := lib.NewRunner(Description)
+	r.LanguageFlag()
+	r.Parse()
+	lang := r.Language()
+	err := r.RunConverter(func(s string) string {
+		return strings.ToUpper(s, lang)
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
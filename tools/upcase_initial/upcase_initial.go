@@ -0,0 +1,36 @@
+package upcaseinitial
+
+import (
+	"flag"
+	"log"
+
+	"golang.org/x/text/language"
+
+	"github.com/HannaLindgren/go-utils/scripts/lib"
+	"github.com/HannaLindgren/go-utils/strings"
+)
+
+// Description is a one-line description of the command
+const Description = "Upcase the initial character of each line"
+
+var lang language.Tag
+
+func convert(s string) string {
+	return strings.UpcaseInitialLang(s, *downcaseRemainder, lang)
+}
+
+var downcaseRemainder *bool
+
+// Main runs the upcase_initial command, using the command line arguments in os.Args
+func Main() {
+	downcaseRemainder = flag.Bool("d", false, "downcase remainder")
+	r := lib.NewRunner(Description)
+	r.LanguageFlag()
+	r.Parse()
+	lang = r.Language()
+
+	err := r.RunConverter(convert)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+}
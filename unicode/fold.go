@@ -0,0 +1,100 @@
+package unicode
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+
+	"github.com/HannaLindgren/go-utils/io"
+)
+
+// DefaultFoldTable maps letters that are not decomposed by NFD into base letters, such as ø => o and ß => ss
+var DefaultFoldTable = map[rune]string{
+	'ø': "o", 'Ø': "O",
+	'æ': "ae", 'Æ': "AE",
+	'œ': "oe", 'Œ': "OE",
+	'ß': "ss", 'ẞ': "SS",
+	'ł': "l", 'Ł': "L",
+	'đ': "d", 'Đ': "D",
+	'ð': "d", 'Ð': "D",
+	'þ': "th", 'Þ': "TH",
+	'ħ': "h", 'Ħ': "H",
+	'ŧ': "t", 'Ŧ': "T",
+	'ı': "i",
+	'ſ': "s",
+}
+
+// Folder folds strings into search keys, so that e.g. Åsa, Asa and ÅSA collide: special letters are replaced using a table, other letters are decomposed (NFD) and combining marks are removed, and the result is optionally case folded
+type Folder struct {
+	// Special maps letters that are not decomposed by NFD, such as ø => o, or letters that should be folded differently, such as ü => ue
+	Special map[rune]string
+	// CaseFold enables case folding (Unicode full case folding, as used for case insensitive comparison)
+	CaseFold bool
+}
+
+// NewFolder creates a folder using a copy of DefaultFoldTable, without case folding
+func NewFolder() Folder {
+	f := Folder{Special: map[rune]string{}}
+	for r, s := range DefaultFoldTable {
+		f.Special[r] = s
+	}
+	return f
+}
+
+// Fold folds the input string (see Folder). The special table is applied to the composed (NFC) characters before decomposition, so that it may hold entries for letters with diacritics, such as ü => ue, and then to the decomposed base letters, so that e.g. Ǿ => O.
+func (f Folder) Fold(s string) string {
+	var res strings.Builder
+	for _, r := range NFC(s) {
+		if rep, ok := f.Special[r]; ok {
+			res.WriteString(rep)
+			continue
+		}
+		for _, d := range NFD(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			}
+			if rep, ok := f.Special[d]; ok {
+				res.WriteString(rep)
+				continue
+			}
+			res.WriteRune(d)
+		}
+	}
+	if f.CaseFold {
+		return cases.Fold().String(res.String())
+	}
+	return res.String()
+}
+
+// Fold folds the input string using the default fold table, and optionally case folding (see Folder)
+func Fold(s string, caseFold bool) string {
+	return Folder{Special: DefaultFoldTable, CaseFold: caseFold}.Fold(s)
+}
+
+// ReadFoldTable reads a fold table from a tab separated file with two fields per line: a single character and its replacement (which may be empty). Empty lines and lines starting with # are ignored.
+func ReadFoldTable(fName string) (map[rune]string, error) {
+	res := map[rune]string{}
+	lines, err := io.ReadFileToLines(fName)
+	if err != nil {
+		return res, err
+	}
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		if len(fs) != 2 {
+			return res, fmt.Errorf("%s:%d: expected 2 tab separated fields, found %d", fName, i+1, len(fs))
+		}
+		from := NFC(fs[0])
+		if utf8.RuneCountInString(from) != 1 {
+			return res, fmt.Errorf("%s:%d: expected a single character, found %s", fName, i+1, fs[0])
+		}
+		r, _ := utf8.DecodeRuneInString(from)
+		res[r] = fs[1]
+	}
+	return res, nil
+}
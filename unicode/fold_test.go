@@ -0,0 +1,74 @@
+package unicode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	var test = func(in string, caseFold bool, exp string) {
+		if got := Fold(in, caseFold); got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("Åsa", false, "Asa")
+	test("ÅSA", false, "ASA")
+	test("Åsa", true, "asa")
+	test("ÅSA", true, "asa")
+	test("Asa", true, "asa")
+	test("Søren Kierkegaard", false, "Soren Kierkegaard")
+	test("Straße", false, "Strasse")
+	test("STRASSE", true, "strasse")
+	test("Ærø", true, "aero")
+	test("Łódź", false, "Lodz")
+	test("Crème brûlée", false, "Creme brulee")
+	test("Ǿ", false, "O")
+	test("plain ascii", false, "plain ascii")
+}
+
+func TestFolder(t *testing.T) {
+	f := NewFolder()
+	f.Special['ø'] = "oe"
+	if got, exp := f.Fold("Ørsted bløder"), "Orsted bloeder"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+	if got, exp := DefaultFoldTable['ø'], "o"; got != exp {
+		t.Errorf("expected default table to be unchanged: "+fsExpGot, exp, got)
+	}
+
+	// entries for letters with a canonical decomposition are used, for composed as well as decomposed input
+	f = NewFolder()
+	f.Special['ü'] = "ue"
+	f.Special['Å'] = "Aa"
+	if got, exp := f.Fold("Müller Ångström Mu\u0308ller"), "Mueller Aangstrom Mueller"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	f = Folder{CaseFold: true}
+	if got, exp := f.Fold("Øre"), "øre"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+}
+
+func TestReadFoldTable(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "fold.tsv")
+	if err := os.WriteFile(fName, []byte("# test table\nø\toe\nÞ\tTh\n\nŒ\t\nü\tue\nå\taa\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	table, err := ReadFoldTable(fName)
+	if err != nil {
+		t.Fatalf("Got error from ReadFoldTable: %v", err)
+	}
+	f := Folder{Special: table}
+	if got, exp := f.Fold("Þórshøfn Œ Müller Ångström"), "Thorshoefn  Mueller Angstrom"; got != exp {
+		t.Errorf(fsExpGot, exp, got)
+	}
+
+	if err := os.WriteFile(fName, []byte("øø\toe\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFoldTable(fName); err == nil {
+		t.Errorf("Expected error for multi-character input")
+	}
+}
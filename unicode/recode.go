@@ -0,0 +1,244 @@
+package unicode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	xunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// normCharsetName lowercases a charset name and removes separators, so that e.g. ISO-8859-1, iso_8859_1 and "ISO 8859-1" are treated as the same name
+func normCharsetName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+}
+
+var charmapsByName = func() map[string]*charmap.Charmap {
+	res := map[string]*charmap.Charmap{}
+	for _, enc := range charmap.All {
+		if cm, ok := enc.(*charmap.Charmap); ok {
+			res[normCharsetName(cm.String())] = cm
+		}
+	}
+	return res
+}()
+
+// multiByteCharsets are the supported charsets, in addition to the single-byte charsets of golang.org/x/text/encoding/charmap
+var multiByteCharsets = []string{"UTF-8", "UTF-16LE", "UTF-16BE", "Shift_JIS", "EUC-JP", "ISO-2022-JP", "EUC-KR", "GBK", "GB18030", "Big5"}
+
+// CharsetNames returns the names of the supported charsets
+func CharsetNames() []string {
+	res := append([]string{}, multiByteCharsets...)
+	single := []string{}
+	for _, cm := range charmapsByName {
+		single = append(single, cm.String())
+	}
+	sort.Strings(single)
+	return append(res, single...)
+}
+
+// LookupCharset returns the encoding for a charset name, such as UTF-8, ISO-8859-1, Windows-1252, UTF-16LE or Shift_JIS. Name matching is case insensitive, and hyphens, underscores and spaces are ignored. Note that ISO-8859-1 is the actual ISO 8859-1 charset, not Windows 1252 (which is what HTML uses for latin1).
+func LookupCharset(name string) (encoding.Encoding, error) {
+	if cm, ok := charmapsByName[normCharsetName(name)]; ok {
+		return cm, nil
+	}
+	if normCharsetName(name) == "utf16" {
+		return xunicode.UTF16(xunicode.BigEndian, xunicode.ExpectBOM), nil
+	}
+	enc, err := htmlindex.Get(name)
+	if err != nil || enc == nil {
+		return nil, fmt.Errorf("unknown charset: %s", name)
+	}
+	return enc, nil
+}
+
+// windows1252Bytes maps the windows 1252 characters in the 0x80-0x9F range back to their byte values
+var windows1252Bytes = func() map[rune]byte {
+	res := map[rune]byte{}
+	for b := 0x80; b <= 0x9F; b++ {
+		r := charmap.Windows1252.DecodeByte(byte(b))
+		if r != utf8.RuneError {
+			res[r] = byte(b)
+		}
+	}
+	return res
+}()
+
+// mojibakeByte returns the byte that was probably misread as r, if UTF-8 data was decoded as Latin-1/Windows 1252
+func mojibakeByte(r rune) (byte, bool) {
+	if r >= 0x80 && r <= 0xFF {
+		return byte(r), true
+	}
+	b, ok := windows1252Bytes[r]
+	return b, ok
+}
+
+func utf8SeqLen(b byte) int {
+	switch {
+	case b >= 0xC2 && b <= 0xDF:
+		return 2
+	case b >= 0xE0 && b <= 0xEF:
+		return 3
+	case b >= 0xF0 && b <= 0xF4:
+		return 4
+	}
+	return 0
+}
+
+// undoMojibake makes a single repair pass over the input; returns false if nothing was repaired
+func undoMojibake(s string) (string, bool) {
+	runes := []rune(s)
+	var res strings.Builder
+	fixed := false
+	for i := 0; i < len(runes); i++ {
+		lead, ok := mojibakeByte(runes[i])
+		n := utf8SeqLen(lead)
+		if !ok || n == 0 || i+n > len(runes) {
+			res.WriteRune(runes[i])
+			continue
+		}
+		seq := []byte{lead}
+		for _, r := range runes[i+1 : i+n] {
+			b, ok := mojibakeByte(r)
+			if !ok || b < 0x80 || b > 0xBF {
+				break
+			}
+			seq = append(seq, b)
+		}
+		if len(seq) != n || !utf8.Valid(seq) {
+			res.WriteRune(runes[i])
+			continue
+		}
+		res.Write(seq)
+		fixed = true
+		i += n - 1
+	}
+	return res.String(), fixed
+}
+
+// HasMojibake returns true if the input contains typical mojibake patterns, i.e., UTF-8 that has been decoded as Latin-1 or Windows 1252, such as Ã¥ for å
+func HasMojibake(s string) bool {
+	_, fixed := undoMojibake(s)
+	return fixed
+}
+
+// maxMojibakePasses is the maximum number of encoding layers removed by FixMojibake
+const maxMojibakePasses = 3
+
+// decodeMojibake maps each character of the input back to the byte it was probably misread from (see mojibakeByte), and decodes the bytes as UTF-8; returns false if a character can't be mapped back, or if the bytes are not valid UTF-8
+func decodeMojibake(s string) (string, bool) {
+	bts := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r < 0x80 {
+			bts = append(bts, byte(r))
+			continue
+		}
+		b, ok := mojibakeByte(r)
+		if !ok {
+			return s, false
+		}
+		bts = append(bts, b)
+	}
+	if !utf8.Valid(bts) {
+		return s, false
+	}
+	return string(bts), true
+}
+
+// FixMojibake reverses typical mojibake patterns, i.e., UTF-8 that has been decoded as Latin-1 or Windows 1252 (and then saved as UTF-8 again), such as Ã¥ for å or â€™ for ’. The input is only repaired if all of it decodes cleanly, so that legitimate text (such as Åsa, or Â· in a line with other non-ASCII text) and mixed input is left unchanged. Repeatedly double-encoded text is repaired as well, up to three layers.
+func FixMojibake(s string) string {
+	for i := 0; i < maxMojibakePasses; i++ {
+		fixed, ok := decodeMojibake(s)
+		if !ok || fixed == s {
+			return s
+		}
+		s = fixed
+	}
+	return s
+}
+
+// Recoder converts text between charsets, line by line
+type Recoder struct {
+	// From is the input charset (nil means UTF-8)
+	From encoding.Encoding
+	// To is the output charset (nil means UTF-8)
+	To encoding.Encoding
+	// FixMojibake reverses typical mojibake patterns (see FixMojibake)
+	FixMojibake bool
+	// InvalidLine, if set, is called for each input line that is not valid UTF-8. Invalid bytes are replaced by the unicode replacement character in the output.
+	InvalidLine func(lineNo int, line string)
+	// FixedLine, if set, is called for each line where mojibake was repaired
+	FixedLine func(lineNo int, line, fixed string)
+}
+
+func isUTF8(enc encoding.Encoding) bool {
+	return enc == nil || enc == xunicode.UTF8
+}
+
+// Recode reads text from r and writes it to w, converted according to the Recoder settings. Line endings are kept as is.
+func (rc Recoder) Recode(r io.Reader, w io.Writer) error {
+	if !isUTF8(rc.From) {
+		r = rc.From.NewDecoder().Reader(r)
+	}
+	if isUTF8(rc.To) {
+		return rc.recodeLines(r, w)
+	}
+	ew := transform.NewWriter(w, rc.To.NewEncoder())
+	if err := rc.recodeLines(r, ew); err != nil {
+		return err
+	}
+	return ew.Close()
+}
+
+func (rc Recoder) recodeLines(r io.Reader, w io.Writer) error {
+	br := bufio.NewReader(r)
+	lineNo := 0
+	for {
+		line, readErr := br.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("couldn't read line %d : %v", lineNo+1, readErr)
+		}
+		if line == "" && readErr == io.EOF {
+			return nil
+		}
+		lineNo++
+		text := strings.TrimSuffix(line, "\n")
+		newline := text != line
+		if !utf8.ValidString(text) {
+			if rc.InvalidLine != nil {
+				rc.InvalidLine(lineNo, text)
+			}
+			text = strings.ToValidUTF8(text, string(utf8.RuneError))
+		}
+		if rc.FixMojibake {
+			fixed := FixMojibake(text)
+			if fixed != text && rc.FixedLine != nil {
+				rc.FixedLine(lineNo, text, fixed)
+			}
+			text = fixed
+		}
+		if newline {
+			text += "\n"
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return fmt.Errorf("couldn't convert line %d : %v", lineNo, err)
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// RecodeString converts a string between charsets, returning the result as bytes in the output charset
+func RecodeString(s string, from, to encoding.Encoding) ([]byte, error) {
+	var buf strings.Builder
+	err := Recoder{From: from, To: to}.Recode(strings.NewReader(s), &buf)
+	return []byte(buf.String()), err
+}
@@ -0,0 +1,84 @@
+package unicode
+
+import (
+	"strings"
+	"testing"
+)
+
+var fsExpGot = "expected: %#v ; got: %#v"
+
+func TestFixMojibake(t *testing.T) {
+	var test = func(in, exp string) {
+		got := FixMojibake(in)
+		if got != exp {
+			t.Errorf(fsExpGot, exp, got)
+		}
+	}
+	test("rÃ¤ksmÃ¶rgÃ¥s", "räksmörgås")
+	test("itâ€™s", "it’s")
+	test("rÃƒÂ¤ksmÃƒÂ¶rgÃƒÂ¥s", "räksmörgås")
+	test("räksmörgås", "räksmörgås")
+	test("Åsa Ölund", "Åsa Ölund")
+	// the input is only repaired if all of it decodes cleanly
+	test("Ã¥ and å", "Ã¥ and å")
+	test("Ðakovo Â· 25°C", "Ðakovo Â· 25°C")
+	test("Ð° and Ðakovo", "Ð° and Ðakovo")
+	test("plain ascii", "plain ascii")
+}
+
+func TestLookupCharset(t *testing.T) {
+	for _, name := range []string{"UTF-8", "utf8", "ISO-8859-1", "iso_8859_15", "Windows-1252", "UTF-16LE", "Shift_JIS"} {
+		if _, err := LookupCharset(name); err != nil {
+			t.Errorf("Got error from LookupCharset for %s: %v", name, err)
+		}
+	}
+	if _, err := LookupCharset("no-such-charset"); err == nil {
+		t.Errorf("Expected error from LookupCharset for unknown charset")
+	}
+	for _, name := range CharsetNames() {
+		if _, err := LookupCharset(name); err != nil {
+			t.Errorf("Got error from LookupCharset for listed charset %s: %v", name, err)
+		}
+	}
+}
+
+func TestRecoder(t *testing.T) {
+	latin1, err := LookupCharset("ISO-8859-1")
+	if err != nil {
+		t.Fatalf("Got error from LookupCharset: %v", err)
+	}
+
+	// latin1 => utf8
+	var out strings.Builder
+	err = Recoder{From: latin1}.Recode(strings.NewReader("r\xe4ksm\xf6rg\xe5s\nhej"), &out)
+	if err != nil {
+		t.Errorf("Got error from Recode: %v", err)
+	}
+	if exp := "räksmörgås\nhej"; out.String() != exp {
+		t.Errorf(fsExpGot, exp, out.String())
+	}
+
+	// utf8 => latin1
+	bts, err := RecodeString("räksmörgås\n", nil, latin1)
+	if err != nil {
+		t.Errorf("Got error from RecodeString: %v", err)
+	}
+	if exp := "r\xe4ksm\xf6rg\xe5s\n"; string(bts) != exp {
+		t.Errorf(fsExpGot, exp, string(bts))
+	}
+
+	// invalid utf8 lines
+	invalid := []int{}
+	out.Reset()
+	rc := Recoder{InvalidLine: func(lineNo int, line string) { invalid = append(invalid, lineNo) }}
+	err = rc.Recode(strings.NewReader("ok\nr\xe4ksm\xf6rg\xe5s\nok\n"), &out)
+	if err != nil {
+		t.Errorf("Got error from Recode: %v", err)
+	}
+	if len(invalid) != 1 || invalid[0] != 2 {
+		t.Errorf(fsExpGot, []int{2}, invalid)
+	}
+	if exp := "ok\nr�ksm�rg�s\nok\n"; out.String() != exp {
+		t.Errorf(fsExpGot, exp, out.String())
+	}
+}